@@ -1,19 +1,20 @@
 package svg
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"strconv"
 	"strings"
 )
 
-const defaultFontSize = 14
+const DefaultFontSize = 14
 
 var (
 	DefaultStroke   = NewStroke("black", 1)
 	DefaultFill     = NewFill("black")
 	TransparentFill = NewFill("transparent")
-	DefaultFont     = NewFont(defaultFontSize)
+	DefaultFont     = NewFont(DefaultFontSize)
 )
 
 const (
@@ -74,6 +75,26 @@ func (c Clipping) Attributes() []string {
 	return attrs
 }
 
+type Markers struct {
+	Start string
+	Mid   string
+	End   string
+}
+
+func (m Markers) Attributes() []string {
+	var attrs []string
+	if m.Start != "" {
+		attrs = append(attrs, appendString("marker-start", UrlFor(m.Start)))
+	}
+	if m.Mid != "" {
+		attrs = append(attrs, appendString("marker-mid", UrlFor(m.Mid)))
+	}
+	if m.End != "" {
+		attrs = append(attrs, appendString("marker-end", UrlFor(m.End)))
+	}
+	return attrs
+}
+
 type Datum struct {
 	Name  string
 	Value interface{}
@@ -135,6 +156,13 @@ func NewFont(size float64, families ...string) Font {
 	}
 }
 
+func (f Font) OrDefault() Font {
+	if f.Size == 0 {
+		return NewFont(DefaultFontSize)
+	}
+	return f
+}
+
 func (f Font) Attributes() []string {
 	var attrs []string
 	values := []struct {
@@ -338,7 +366,9 @@ func (f Fill) Attributes() []string {
 	if f.Rule != "" {
 		attrs = append(attrs, appendString("fill-rule", f.Rule))
 	}
-	attrs = append(attrs, appendFloat("fill-opacity", f.Opacity))
+	if f.Opacity > 0 && f.Opacity < 1 {
+		attrs = append(attrs, appendFloat("fill-opacity", f.Opacity))
+	}
 	return attrs
 }
 
@@ -439,7 +469,7 @@ func appendFunc(name string, list ...float64) string {
 		if i > 0 {
 			buf = append(buf, comma)
 		}
-		buf = strconv.AppendFloat(buf, list[i], 'f', getPrecision(list[i]), 64)
+		buf = appendNumber(buf, list[i])
 	}
 	buf = append(buf, rparen)
 	return string(buf)
@@ -456,15 +486,23 @@ func appendInt(attr string, v int64) string {
 func appendFloat(attr string, v float64) string {
 	buf := []byte(attr)
 	buf = append(buf, equal, quote)
-	buf = strconv.AppendFloat(buf, v, 'f', getPrecision(v), 64)
+	buf = appendNumber(buf, v)
 	buf = append(buf, quote)
 	return string(buf)
 }
 
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+)
+
 func appendString(attr, v string) string {
 	buf := []byte(attr)
-	buf = append(buf, equal)
-	buf = strconv.AppendQuote(buf, v)
+	buf = append(buf, equal, quote)
+	buf = append(buf, escaper.Replace(v)...)
+	buf = append(buf, quote)
 	return string(buf)
 }
 
@@ -478,7 +516,7 @@ func appendStringArray(attr string, list []string, sep byte) string {
 				buf = append(buf, space)
 			}
 		}
-		buf = append(buf, []byte(list[i])...)
+		buf = append(buf, escaper.Replace(list[i])...)
 	}
 	buf = append(buf, quote)
 	return string(buf)
@@ -504,7 +542,7 @@ func appendFloatArray(attr string, list []float64, sep byte) string {
 		if i > 0 {
 			buf = append(buf, sep)
 		}
-		buf = strconv.AppendFloat(buf, list[i], 'f', getPrecision(list[i]), 64)
+		buf = appendNumber(buf, list[i])
 	}
 	buf = append(buf, quote)
 	return string(buf)
@@ -517,14 +555,30 @@ func appendFloatPair(attr string, list []float64) string {
 		if i > 0 {
 			buf = append(buf, space)
 		}
-		buf = strconv.AppendFloat(buf, list[i], 'f', getPrecision(list[i]), 64)
+		buf = appendNumber(buf, list[i])
 		buf = append(buf, comma)
-		buf = strconv.AppendFloat(buf, list[i+1], 'f', getPrecision(list[i+1]), 64)
+		buf = appendNumber(buf, list[i+1])
 	}
 	buf = append(buf, quote)
 	return string(buf)
 }
 
+func appendNumber(buf []byte, f float64) []byte {
+	var (
+		offset = len(buf)
+		prec   = getPrecision(f)
+	)
+	buf = strconv.AppendFloat(buf, f, 'f', prec, 64)
+	if prec > 0 {
+		buf = bytes.TrimRight(buf, "0")
+		buf = bytes.TrimSuffix(buf, []byte{'.'})
+	}
+	if string(buf[offset:]) == "-0" {
+		buf = append(buf[:offset], '0')
+	}
+	return buf
+}
+
 func getPrecision(f float64) int {
 	if math.Ceil(f) == f {
 		return 0
@@ -0,0 +1,25 @@
+package svg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFillAttributes(t *testing.T) {
+	data := []struct {
+		Fill Fill
+		Want []string
+	}{
+		{Fill: Fill{}, Want: nil},
+		{Fill: Fill{Color: "red"}, Want: []string{`fill="red"`}},
+		{Fill: NewFill("red"), Want: []string{`fill="red"`}},
+		{Fill: Fill{Color: "red", Opacity: 1}, Want: []string{`fill="red"`}},
+		{Fill: Fill{Color: "red", Opacity: 0.5}, Want: []string{`fill="red"`, `fill-opacity="0.5"`}},
+		{Fill: Fill{Color: "red", Rule: "evenodd", Opacity: 0.25}, Want: []string{`fill="red"`, `fill-rule="evenodd"`, `fill-opacity="0.25"`}},
+	}
+	for _, d := range data {
+		if got := d.Fill.Attributes(); !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%+v: want %v, got %v", d.Fill, d.Want, got)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package barcode
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/midbel/svg"
+)
+
+var (
+	ErrInvalid   = errors.New("invalid character")
+	ErrLength    = errors.New("invalid length")
+	ErrChecksum  = errors.New("invalid check digit")
+	ErrSymbology = errors.New("unknown symbology")
+)
+
+const (
+	defaultModule = 2
+	defaultHeight = 50
+	defaultQuiet  = 10
+	textGap       = 2
+)
+
+type Symbology int
+
+const (
+	SymbologyCode128 Symbology = iota
+	SymbologyEAN13
+	SymbologyUPCA
+	SymbologyCode39
+)
+
+type Group struct {
+	Text  string
+	Start int
+	End   int
+}
+
+type Code struct {
+	Text    string
+	Modules []bool
+	Guards  []bool
+	Groups  []Group
+}
+
+func (c *Code) widths(pattern string) {
+	for i := 0; i < len(pattern); i++ {
+		dark := i%2 == 0
+		for j := 0; j < int(pattern[i]-'0'); j++ {
+			c.append(dark, false)
+		}
+	}
+}
+
+func (c *Code) bits(pattern string, guard bool) {
+	for i := 0; i < len(pattern); i++ {
+		c.append(pattern[i] == '1', guard)
+	}
+}
+
+func (c *Code) append(dark, guard bool) {
+	c.Modules = append(c.Modules, dark)
+	c.Guards = append(c.Guards, guard)
+}
+
+func (c Code) guard(i int) bool {
+	return i < len(c.Guards) && c.Guards[i]
+}
+
+func (c Code) bars(fn func(start, width int, guard bool)) {
+	for i := 0; i < len(c.Modules); {
+		if !c.Modules[i] {
+			i++
+			continue
+		}
+		var (
+			start = i
+			guard = c.guard(i)
+		)
+		for i < len(c.Modules) && c.Modules[i] && c.guard(i) == guard {
+			i++
+		}
+		fn(start, i-start, guard)
+	}
+}
+
+func (c Code) Path(module, height, extra float64, quiet int) svg.Path {
+	var path svg.Path
+	c.bars(func(start, width int, guard bool) {
+		h := height
+		if guard {
+			h += extra
+		}
+		path.AbsMoveTo(svg.NewPos(float64(start+quiet)*module, 0))
+		path.RelHorizontalLine(float64(width) * module)
+		path.RelVerticalLine(h)
+		path.RelHorizontalLine(-float64(width) * module)
+		path.ClosePath()
+	})
+	return path
+}
+
+func (c Code) Rects(module, height, extra float64, quiet int) []svg.Rect {
+	var list []svg.Rect
+	c.bars(func(start, width int, guard bool) {
+		var rect svg.Rect
+		rect.Pos = svg.NewPos(float64(start+quiet)*module, 0)
+		rect.Dim = svg.NewDim(float64(width)*module, height)
+		if guard {
+			rect.Dim.H += extra
+		}
+		list = append(list, rect)
+	})
+	return list
+}
+
+type Barcode struct {
+	Symbology  Symbology
+	Data       string
+	Check      bool
+	Module     float64
+	Height     float64
+	Quiet      int
+	Rects      bool
+	OmitText   bool
+	Font       svg.Font
+	Color      string
+	Background string
+}
+
+func (b Barcode) Encode() (Code, error) {
+	switch b.Symbology {
+	case SymbologyCode128:
+		return Code128(b.Data)
+	case SymbologyEAN13:
+		return EAN13(b.Data)
+	case SymbologyUPCA:
+		return UPCA(b.Data)
+	case SymbologyCode39:
+		return Code39(b.Data, b.Check)
+	default:
+		return Code{}, fmt.Errorf("%w: %d", ErrSymbology, b.Symbology)
+	}
+}
+
+func (b Barcode) Render(w io.Writer) error {
+	code, err := b.Encode()
+	if err != nil {
+		return err
+	}
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := b.render(code)
+	el.Render(ws)
+	return nil
+}
+
+func (b Barcode) Element() svg.Element {
+	code, _ := b.Encode()
+	return b.render(code)
+}
+
+func (b Barcode) render(code Code) svg.Element {
+	var (
+		canvas = svg.NewSVG()
+		module = b.module()
+		height = b.height()
+		quiet  = b.quiet()
+		font   = b.font()
+		extra  float64
+	)
+	if !b.OmitText {
+		extra = font.Size/2 + textGap
+	}
+	var (
+		width = float64(len(code.Modules)+2*quiet) * module
+		total = height
+	)
+	if !b.OmitText {
+		total += font.Size + textGap
+	}
+	canvas.Dim = svg.NewDim(width, total)
+	canvas.Class = append(canvas.Class, "barcode")
+	canvas.Data = append(canvas.Data, svg.Datum{Name: "value", Value: code.Text})
+	if b.Background != "none" {
+		var rect svg.Rect
+		rect.Dim = canvas.Dim
+		rect.Fill = svg.NewFill(b.background())
+		canvas.Append(rect.AsElement())
+	}
+	if b.Rects {
+		var grp svg.Group
+		grp.Class = append(grp.Class, "bars")
+		grp.Fill = svg.NewFill(b.color())
+		rects := code.Rects(module, height, extra, quiet)
+		for i := range rects {
+			grp.Append(rects[i].AsElement())
+		}
+		canvas.Append(grp.AsElement())
+	} else {
+		path := code.Path(module, height, extra, quiet)
+		path.Class = append(path.Class, "bars")
+		path.Fill = svg.NewFill(b.color())
+		canvas.Append(path.AsElement())
+	}
+	if !b.OmitText {
+		var grp svg.Group
+		grp.Class = append(grp.Class, "text")
+		for _, g := range code.Groups {
+			text := svg.NewText(g.Text)
+			text.Font = font
+			text.Font.Fill = b.color()
+			text.Anchor = "middle"
+			text.Pos = svg.NewPos(float64(g.Start+g.End+2*quiet)*module/2, total)
+			grp.Append(text.AsElement())
+		}
+		canvas.Append(grp.AsElement())
+	}
+	return canvas.AsElement()
+}
+
+func (b Barcode) module() float64 {
+	if b.Module <= 0 {
+		return defaultModule
+	}
+	return b.Module
+}
+
+func (b Barcode) height() float64 {
+	if b.Height <= 0 {
+		return defaultHeight
+	}
+	return b.Height
+}
+
+func (b Barcode) quiet() int {
+	if b.Quiet < 0 {
+		return 0
+	}
+	if b.Quiet == 0 {
+		return defaultQuiet
+	}
+	return b.Quiet
+}
+
+func (b Barcode) font() svg.Font {
+	if b.Font.Size == 0 {
+		return svg.NewFont(svg.DefaultFontSize, "monospace")
+	}
+	return b.Font
+}
+
+func (b Barcode) color() string {
+	if b.Color == "" {
+		return "#000000"
+	}
+	return b.Color
+}
+
+func (b Barcode) background() string {
+	if b.Background == "" {
+		return "#ffffff"
+	}
+	return b.Background
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,223 @@
+package barcode
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCode128(t *testing.T) {
+	data := []struct {
+		Input  string
+		Values []int
+	}{
+		{
+			Input:  "Wikipedia",
+			Values: []int{104, 55, 73, 75, 73, 80, 69, 68, 73, 65, 88, 106},
+		},
+		{
+			Input:  "123456",
+			Values: []int{105, 12, 34, 56, 44, 106},
+		},
+		{
+			Input:  "12345",
+			Values: []int{105, 12, 34, 100, 21, 54, 106},
+		},
+		{
+			Input:  "AB1234",
+			Values: []int{104, 33, 34, 99, 12, 34, 102, 106},
+		},
+		{
+			Input:  "\tA",
+			Values: []int{103, 73, 33, 36, 106},
+		},
+	}
+	for _, d := range data {
+		code, err := Code128(d.Input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if want := 11*len(d.Values) + 2; len(code.Modules) != want {
+			t.Errorf("%q: want %d modules, got %d", d.Input, want, len(code.Modules))
+		}
+		if got := decode128(code.Modules); !reflect.DeepEqual(got, d.Values) {
+			t.Errorf("%q: values mismatched: want %v, got %v", d.Input, d.Values, got)
+		}
+	}
+}
+
+func TestCode128Table(t *testing.T) {
+	seen := make(map[string]bool)
+	for i, p := range code128 {
+		var sum int
+		for j := 0; j < len(p); j++ {
+			sum += int(p[j] - '0')
+		}
+		want := 11
+		if i == code128Stop {
+			want = 13
+		}
+		if sum != want {
+			t.Errorf("value %d: pattern %s has %d modules", i, p, sum)
+		}
+		if seen[p] {
+			t.Errorf("value %d: duplicate pattern %s", i, p)
+		}
+		seen[p] = true
+	}
+	for v, want := range map[int]string{0: "212222", code128StartA: "211412", code128StartB: "211214", code128StartC: "211232", code128Stop: "2331112"} {
+		if code128[v] != want {
+			t.Errorf("value %d: want %s, got %s", v, want, code128[v])
+		}
+	}
+}
+
+func TestEAN13(t *testing.T) {
+	data := []struct {
+		Input string
+		Want  string
+	}{
+		{Input: "400638133393", Want: "4006381333931"},
+		{Input: "4006381333931", Want: "4006381333931"},
+		{Input: "590123412345", Want: "5901234123457"},
+	}
+	for _, d := range data {
+		code, err := EAN13(d.Input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if code.Text != d.Want {
+			t.Errorf("%s: want %s, got %s", d.Input, d.Want, code.Text)
+		}
+		if len(code.Modules) != 95 {
+			t.Errorf("%s: want 95 modules, got %d", d.Input, len(code.Modules))
+		}
+	}
+	code, _ := EAN13("4006381333931")
+	for _, p := range []struct {
+		Start int
+		Want  string
+	}{
+		{Start: 0, Want: "101"},
+		{Start: 3, Want: "0001101"},
+		{Start: 10, Want: "0100111"},
+		{Start: 45, Want: "01010"},
+		{Start: 85, Want: "1100110"},
+		{Start: 92, Want: "101"},
+	} {
+		if got := modules(code, p.Start, len(p.Want)); got != p.Want {
+			t.Errorf("modules at %d: want %s, got %s", p.Start, p.Want, got)
+		}
+	}
+}
+
+func TestUPCA(t *testing.T) {
+	code, err := UPCA("03600029145")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if code.Text != "0036000291452" {
+		t.Errorf("want 0036000291452, got %s", code.Text)
+	}
+	if len(code.Modules) != 95 {
+		t.Errorf("want 95 modules, got %d", len(code.Modules))
+	}
+	if got := modules(code, 3, 7); got != "0001101" {
+		t.Errorf("first digit: want 0001101, got %s", got)
+	}
+	if !code.Guards[3] || !code.Guards[91] || code.Guards[10] {
+		t.Errorf("first and last digits should extend as guards")
+	}
+}
+
+func TestCode39(t *testing.T) {
+	for c, want := range map[byte]string{
+		'*': "nwnnwnwnn",
+		'A': "wnnnnwnnw",
+		'0': "nnnwwnwnn",
+		'1': "wnnwnnnnw",
+		'$': "nwnwnwnnn",
+	} {
+		if got := code39(c); got != want {
+			t.Errorf("%c: want %s, got %s", c, want, got)
+		}
+	}
+	code, err := Code39("CODE39", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if code.Text != "CODE39W" {
+		t.Errorf("want CODE39W, got %s", code.Text)
+	}
+	if want := 9*15 + 8; len(code.Modules) != want {
+		t.Errorf("want %d modules, got %d", want, len(code.Modules))
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	data := []struct {
+		Barcode Barcode
+		Err     error
+	}{
+		{Barcode: Barcode{Symbology: SymbologyCode128}, Err: ErrLength},
+		{Barcode: Barcode{Symbology: SymbologyCode128, Data: "caf\xe9"}, Err: ErrInvalid},
+		{Barcode: Barcode{Symbology: SymbologyEAN13, Data: "4006381333932"}, Err: ErrChecksum},
+		{Barcode: Barcode{Symbology: SymbologyEAN13, Data: "40063813339"}, Err: ErrLength},
+		{Barcode: Barcode{Symbology: SymbologyUPCA, Data: "0360002914A"}, Err: ErrInvalid},
+		{Barcode: Barcode{Symbology: SymbologyCode39, Data: "code39"}, Err: ErrInvalid},
+		{Barcode: Barcode{Symbology: Symbology(42), Data: "42"}, Err: ErrSymbology},
+	}
+	for _, d := range data {
+		if _, err := d.Barcode.Encode(); !errors.Is(err, d.Err) {
+			t.Errorf("%q: want %v, got %v", d.Barcode.Data, d.Err, err)
+		}
+		if err := d.Barcode.Render(ioutil.Discard); !errors.Is(err, d.Err) {
+			t.Errorf("%q: render: want %v, got %v", d.Barcode.Data, d.Err, err)
+		}
+	}
+}
+
+func decode128(list []bool) []int {
+	var (
+		values []int
+		widths []byte
+	)
+	for i := 0; i < len(list); {
+		j := i
+		for j < len(list) && list[j] == list[i] {
+			j++
+		}
+		widths = append(widths, byte('0'+j-i))
+		i = j
+	}
+	for len(widths) >= 6 {
+		n := 6
+		if len(widths) == 7 {
+			n = 7
+		}
+		for v, p := range code128 {
+			if p == string(widths[:n]) {
+				values = append(values, v)
+				break
+			}
+		}
+		widths = widths[n:]
+	}
+	return values
+}
+
+func modules(code Code, start, n int) string {
+	var buf strings.Builder
+	for _, dark := range code.Modules[start : start+n] {
+		if dark {
+			buf.WriteByte('1')
+		} else {
+			buf.WriteByte('0')
+		}
+	}
+	return buf.String()
+}
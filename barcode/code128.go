@@ -0,0 +1,121 @@
+package barcode
+
+import (
+	"fmt"
+)
+
+const (
+	code128StartA = 103
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128CodeA  = 101
+	code128Digits = 4
+)
+
+var code128 = []string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+func Code128(str string) (Code, error) {
+	for i := 0; i < len(str); i++ {
+		if str[i] > 127 {
+			return Code{}, fmt.Errorf("%w: %q at position %d", ErrInvalid, str[i], i)
+		}
+	}
+	if str == "" {
+		return Code{}, fmt.Errorf("%w: empty input", ErrLength)
+	}
+	var (
+		values []int
+		set    int
+	)
+	switch n := digits(str, 0); {
+	case n >= code128Digits || n == len(str) && n%2 == 0:
+		set = code128StartC
+	case needA(str[0]):
+		set = code128StartA
+	default:
+		set = code128StartB
+	}
+	values = append(values, set)
+	for i := 0; i < len(str); {
+		if set == code128StartC {
+			if digits(str, i) >= 2 {
+				values = append(values, int(str[i]-'0')*10+int(str[i+1]-'0'))
+				i += 2
+				continue
+			}
+			if needA(str[i]) {
+				set = code128StartA
+				values = append(values, code128CodeA)
+			} else {
+				set = code128StartB
+				values = append(values, code128CodeB)
+			}
+			continue
+		}
+		if n := digits(str, i); n >= code128Digits {
+			if n%2 == 1 {
+				values = append(values, value128(str[i], set))
+				i++
+			}
+			set = code128StartC
+			values = append(values, code128CodeC)
+			continue
+		}
+		switch c := str[i]; {
+		case set == code128StartB && needA(c):
+			set = code128StartA
+			values = append(values, code128CodeA)
+		case set == code128StartA && c >= 96:
+			set = code128StartB
+			values = append(values, code128CodeB)
+		}
+		values = append(values, value128(str[i], set))
+		i++
+	}
+	sum := values[0]
+	for i := 1; i < len(values); i++ {
+		sum += i * values[i]
+	}
+	values = append(values, sum%103, code128Stop)
+
+	var c Code
+	for _, v := range values {
+		c.widths(code128[v])
+	}
+	c.Text = str
+	c.Groups = []Group{{Text: str, Start: 0, End: len(c.Modules)}}
+	return c, nil
+}
+
+func value128(c byte, set int) int {
+	if set == code128StartA && c < 32 {
+		return int(c) + 64
+	}
+	return int(c) - 32
+}
+
+func needA(c byte) bool {
+	return c < 32
+}
+
+func digits(str string, i int) int {
+	n := 0
+	for ; i+n < len(str) && isDigit(str[i+n]); n++ {
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	code39Chars  = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+	code39Groups = "1234567890ABCDEFGHIJKLMNOPQRSTUVWXYZ-. *"
+	code39Wide   = 3
+)
+
+var code39Bars = [][2]int{
+	{0, 4}, {1, 4}, {0, 1}, {2, 4}, {0, 2}, {1, 2}, {3, 4}, {0, 3}, {1, 3}, {2, 3},
+}
+
+var code39Spaces = []int{1, 2, 3, 0}
+
+var code39Special = map[byte][3]int{
+	'$': {0, 1, 2},
+	'/': {0, 1, 3},
+	'+': {0, 2, 3},
+	'%': {1, 2, 3},
+}
+
+func Code39(str string, check bool) (Code, error) {
+	if str == "" {
+		return Code{}, fmt.Errorf("%w: empty input", ErrLength)
+	}
+	var sum int
+	for i := 0; i < len(str); i++ {
+		x := strings.IndexByte(code39Chars, str[i])
+		if x < 0 {
+			return Code{}, fmt.Errorf("%w: %q at position %d", ErrInvalid, str[i], i)
+		}
+		sum += x
+	}
+	text := str
+	if check {
+		text += string(code39Chars[sum%len(code39Chars)])
+	}
+	var code Code
+	for i, c := range []byte("*" + text + "*") {
+		if i > 0 {
+			code.append(false, false)
+		}
+		pattern := code39(c)
+		for j := 0; j < len(pattern); j++ {
+			n := 1
+			if pattern[j] == 'w' {
+				n = code39Wide
+			}
+			for k := 0; k < n; k++ {
+				code.append(j%2 == 0, false)
+			}
+		}
+	}
+	code.Text = text
+	code.Groups = []Group{{Text: text, Start: 0, End: len(code.Modules)}}
+	return code, nil
+}
+
+func code39(c byte) string {
+	pattern := []byte("nnnnnnnnn")
+	if spaces, ok := code39Special[c]; ok {
+		for _, s := range spaces {
+			pattern[2*s+1] = 'w'
+		}
+		return string(pattern)
+	}
+	var (
+		x    = strings.IndexByte(code39Groups, c)
+		bars = code39Bars[x%10]
+	)
+	pattern[2*bars[0]] = 'w'
+	pattern[2*bars[1]] = 'w'
+	pattern[2*code39Spaces[x/10]+1] = 'w'
+	return string(pattern)
+}
@@ -0,0 +1,122 @@
+package barcode
+
+import (
+	"fmt"
+)
+
+const (
+	eanGuard  = "101"
+	eanCenter = "01010"
+	eanDigit  = 7
+)
+
+var eanLeft = []string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+var eanParity = []string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+func EAN13(str string) (Code, error) {
+	str, err := checkDigit(str, 12)
+	if err != nil {
+		return Code{}, err
+	}
+	code := ean(str)
+	code.Groups = []Group{
+		{Text: str[:1], Start: -eanDigit, End: 0},
+		{Text: str[1:7], Start: len(eanGuard), End: len(eanGuard) + 6*eanDigit},
+		{Text: str[7:], Start: len(code.Modules) - len(eanGuard) - 6*eanDigit, End: len(code.Modules) - len(eanGuard)},
+	}
+	return code, nil
+}
+
+func UPCA(str string) (Code, error) {
+	str, err := checkDigit(str, 11)
+	if err != nil {
+		return Code{}, err
+	}
+	code := ean("0" + str)
+	for i := 0; i < eanDigit; i++ {
+		code.Guards[len(eanGuard)+i] = true
+		code.Guards[len(code.Modules)-len(eanGuard)-1-i] = true
+	}
+	var (
+		left  = len(eanGuard) + eanDigit
+		right = len(code.Modules) - len(eanGuard) - eanDigit
+	)
+	code.Groups = []Group{
+		{Text: str[:1], Start: -eanDigit, End: 0},
+		{Text: str[1:6], Start: left, End: left + 5*eanDigit},
+		{Text: str[6:11], Start: right - 5*eanDigit, End: right},
+		{Text: str[11:], Start: len(code.Modules), End: len(code.Modules) + eanDigit},
+	}
+	return code, nil
+}
+
+func ean(str string) Code {
+	var (
+		code   = Code{Text: str}
+		parity = eanParity[str[0]-'0']
+	)
+	code.bits(eanGuard, true)
+	for i := 1; i <= 6; i++ {
+		pattern := eanLeft[str[i]-'0']
+		if parity[i-1] == 'G' {
+			pattern = reverse(invert(pattern))
+		}
+		code.bits(pattern, false)
+	}
+	code.bits(eanCenter, true)
+	for i := 7; i <= 12; i++ {
+		code.bits(invert(eanLeft[str[i]-'0']), false)
+	}
+	code.bits(eanGuard, true)
+	return code
+}
+
+func checkDigit(str string, size int) (string, error) {
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) {
+			return "", fmt.Errorf("%w: %q at position %d", ErrInvalid, str[i], i)
+		}
+	}
+	if len(str) != size && len(str) != size+1 {
+		return "", fmt.Errorf("%w: %d digits (want %d or %d)", ErrLength, len(str), size, size+1)
+	}
+	var sum int
+	for i := 0; i < size; i++ {
+		d := int(str[size-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	check := byte('0' + (10-sum%10)%10)
+	if len(str) == size {
+		return str + string(check), nil
+	}
+	if str[size] != check {
+		return "", fmt.Errorf("%w: got %c, want %c", ErrChecksum, str[size], check)
+	}
+	return str, nil
+}
+
+func invert(pattern string) string {
+	buf := []byte(pattern)
+	for i := range buf {
+		buf[i] ^= 1
+	}
+	return string(buf)
+}
+
+func reverse(pattern string) string {
+	buf := []byte(pattern)
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return string(buf)
+}
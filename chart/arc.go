@@ -0,0 +1,308 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/midbel/svg"
+)
+
+const epsilon = 1e-12
+
+type Arc struct {
+	svg.Pos
+	Inner  float64
+	Outer  float64
+	Corner float64
+	Starts float64
+	Ends   float64
+	Pad    float64
+}
+
+func (a Arc) Centroid() svg.Pos {
+	var (
+		r     = (a.Inner + a.Outer) / 2
+		angle = (a.Starts + a.Ends) / 2
+	)
+	return a.point(r, angle)
+}
+
+func (a Arc) Point(radius float64) svg.Pos {
+	return a.point(radius, (a.Starts+a.Ends)/2)
+}
+
+func (a Arc) Path() svg.Path {
+	var (
+		pen = arcPen{center: a.Pos}
+		r0  = a.Inner
+		r1  = a.Outer
+		a0  = a.Starts - math.Pi/2
+		a1  = a.Ends - math.Pi/2
+		da  = math.Abs(a1 - a0)
+		cw  = a1 > a0
+	)
+	if r1 < r0 {
+		r0, r1 = r1, r0
+	}
+	if r1 <= epsilon {
+		pen.moveTo(0, 0)
+		return pen.path
+	}
+	if da > 2*math.Pi-epsilon {
+		pen.moveTo(r1*math.Cos(a0), r1*math.Sin(a0))
+		pen.arc(0, 0, r1, a0, a1, !cw)
+		if r0 > epsilon {
+			pen.moveTo(r0*math.Cos(a1), r0*math.Sin(a1))
+			pen.arc(0, 0, r0, a1, a0, cw)
+		}
+		pen.path.ClosePath()
+		return pen.path
+	}
+	var (
+		a01 = a0
+		a11 = a1
+		a00 = a0
+		a10 = a1
+		da0 = da
+		da1 = da
+		ap  = a.Pad / 2
+		rp  = math.Sqrt(r0*r0 + r1*r1)
+		rc  = math.Min(math.Abs(r1-r0)/2, a.Corner)
+		rc0 = rc
+		rc1 = rc
+	)
+	if ap > epsilon && rp > epsilon {
+		var (
+			p0 = asin(rp / r0 * math.Sin(ap))
+			p1 = asin(rp / r1 * math.Sin(ap))
+		)
+		if r0 <= epsilon {
+			p0 = 0
+		}
+		if da0 -= p0 * 2; da0 > epsilon {
+			if !cw {
+				p0 = -p0
+			}
+			a00 += p0
+			a10 -= p0
+		} else {
+			da0 = 0
+			a00 = (a0 + a1) / 2
+			a10 = a00
+		}
+		if da1 -= p1 * 2; da1 > epsilon {
+			if !cw {
+				p1 = -p1
+			}
+			a01 += p1
+			a11 -= p1
+		} else {
+			da1 = 0
+			a01 = (a0 + a1) / 2
+			a11 = a01
+		}
+	}
+	var (
+		x01 = r1 * math.Cos(a01)
+		y01 = r1 * math.Sin(a01)
+		x10 = r0 * math.Cos(a10)
+		y10 = r0 * math.Sin(a10)
+		x11 = r1 * math.Cos(a11)
+		y11 = r1 * math.Sin(a11)
+		x00 = r0 * math.Cos(a00)
+		y00 = r0 * math.Sin(a00)
+	)
+	if rc > epsilon && da < math.Pi {
+		if ox, oy, ok := intersect(x01, y01, x00, y00, x11, y11, x10, y10); ok {
+			var (
+				ax = x01 - ox
+				ay = y01 - oy
+				bx = x11 - ox
+				by = y11 - oy
+				kc = 1 / math.Sin(math.Acos((ax*bx+ay*by)/(math.Hypot(ax, ay)*math.Hypot(bx, by)))/2)
+				lc = math.Hypot(ox, oy)
+			)
+			rc0 = math.Min(rc, (r0-lc)/(kc-1))
+			rc1 = math.Min(rc, (r1-lc)/(kc+1))
+		}
+	}
+	switch {
+	case da1 <= epsilon:
+		pen.moveTo(x01, y01)
+	case rc1 > epsilon:
+		var (
+			t0 = cornerTangents(x00, y00, x01, y01, r1, rc1, cw)
+			t1 = cornerTangents(x11, y11, x10, y10, r1, rc1, cw)
+		)
+		pen.moveTo(t0.cx+t0.x01, t0.cy+t0.y01)
+		if rc1 < rc {
+			pen.arc(t0.cx, t0.cy, rc1, math.Atan2(t0.y01, t0.x01), math.Atan2(t1.y01, t1.x01), !cw)
+		} else {
+			pen.arc(t0.cx, t0.cy, rc1, math.Atan2(t0.y01, t0.x01), math.Atan2(t0.y11, t0.x11), !cw)
+			pen.arc(0, 0, r1, math.Atan2(t0.cy+t0.y11, t0.cx+t0.x11), math.Atan2(t1.cy+t1.y11, t1.cx+t1.x11), !cw)
+			pen.arc(t1.cx, t1.cy, rc1, math.Atan2(t1.y11, t1.x11), math.Atan2(t1.y01, t1.x01), !cw)
+		}
+	default:
+		pen.moveTo(x01, y01)
+		pen.arc(0, 0, r1, a01, a11, !cw)
+	}
+	switch {
+	case r0 <= epsilon || da0 <= epsilon:
+		pen.lineTo(x10, y10)
+	case rc0 > epsilon:
+		var (
+			t0 = cornerTangents(x10, y10, x11, y11, r0, -rc0, cw)
+			t1 = cornerTangents(x01, y01, x00, y00, r0, -rc0, cw)
+		)
+		pen.lineTo(t0.cx+t0.x01, t0.cy+t0.y01)
+		if rc0 < rc {
+			pen.arc(t0.cx, t0.cy, rc0, math.Atan2(t0.y01, t0.x01), math.Atan2(t1.y01, t1.x01), !cw)
+		} else {
+			pen.arc(t0.cx, t0.cy, rc0, math.Atan2(t0.y01, t0.x01), math.Atan2(t0.y11, t0.x11), !cw)
+			pen.arc(0, 0, r0, math.Atan2(t0.cy+t0.y11, t0.cx+t0.x11), math.Atan2(t1.cy+t1.y11, t1.cx+t1.x11), cw)
+			pen.arc(t1.cx, t1.cy, rc0, math.Atan2(t1.y11, t1.x11), math.Atan2(t1.y01, t1.x01), !cw)
+		}
+	default:
+		pen.arc(0, 0, r0, a10, a00, cw)
+	}
+	pen.path.ClosePath()
+	return pen.path
+}
+
+func (a Arc) point(radius, angle float64) svg.Pos {
+	return svg.NewPos(a.X+radius*math.Sin(angle), a.Y-radius*math.Cos(angle))
+}
+
+type arcPen struct {
+	path   svg.Path
+	center svg.Pos
+	curr   svg.Pos
+}
+
+func (p *arcPen) moveTo(x, y float64) {
+	p.curr = p.center.Adjust(x, y)
+	p.path.AbsMoveTo(p.curr)
+}
+
+func (p *arcPen) lineTo(x, y float64) {
+	p.curr = p.center.Adjust(x, y)
+	p.path.AbsLineTo(p.curr)
+}
+
+func (p *arcPen) arc(x, y, r, a0, a1 float64, ccw bool) {
+	var (
+		start = p.center.Adjust(x+r*math.Cos(a0), y+r*math.Sin(a0))
+		da    = a1 - a0
+	)
+	if ccw {
+		da = a0 - a1
+	}
+	if math.Abs(start.X-p.curr.X) > epsilon || math.Abs(start.Y-p.curr.Y) > epsilon {
+		p.path.AbsLineTo(start)
+	}
+	if r <= epsilon {
+		p.curr = start
+		return
+	}
+	if da < 0 {
+		da = math.Mod(da, 2*math.Pi) + 2*math.Pi
+	}
+	if da > 2*math.Pi-epsilon {
+		var (
+			half = a0 + math.Pi
+			end  = p.center.Adjust(x+r*math.Cos(a0), y+r*math.Sin(a0))
+		)
+		mid := p.center.Adjust(x+r*math.Cos(half), y+r*math.Sin(half))
+		p.path.AbsArcTo(mid, r, r, 0, true, !ccw)
+		p.path.AbsArcTo(end, r, r, 0, true, !ccw)
+		p.curr = end
+		return
+	}
+	end := p.center.Adjust(x+r*math.Cos(a1), y+r*math.Sin(a1))
+	p.path.AbsArcTo(end, r, r, 0, da >= math.Pi, !ccw)
+	p.curr = end
+}
+
+type tangent struct {
+	cx  float64
+	cy  float64
+	x01 float64
+	y01 float64
+	x11 float64
+	y11 float64
+}
+
+func cornerTangents(x0, y0, x1, y1, r1, rc float64, cw bool) tangent {
+	var (
+		x01 = x0 - x1
+		y01 = y0 - y1
+		lo  = rc / math.Hypot(x01, y01)
+	)
+	if !cw {
+		lo = -lo
+	}
+	var (
+		ox  = lo * y01
+		oy  = -lo * x01
+		x11 = x0 + ox
+		y11 = y0 + oy
+		x10 = x1 + ox
+		y10 = y1 + oy
+		x00 = (x11 + x10) / 2
+		y00 = (y11 + y10) / 2
+		dx  = x10 - x11
+		dy  = y10 - y11
+		d2  = dx*dx + dy*dy
+		r   = r1 - rc
+		dd  = x11*y10 - x10*y11
+		d   = math.Sqrt(math.Max(0, r*r*d2-dd*dd))
+	)
+	if dy < 0 {
+		d = -d
+	}
+	var (
+		cx0 = (dd*dy - dx*d) / d2
+		cy0 = (-dd*dx - dy*d) / d2
+		cx1 = (dd*dy + dx*d) / d2
+		cy1 = (-dd*dx + dy*d) / d2
+		dx0 = cx0 - x00
+		dy0 = cy0 - y00
+		dx1 = cx1 - x00
+		dy1 = cy1 - y00
+	)
+	if dx0*dx0+dy0*dy0 > dx1*dx1+dy1*dy1 {
+		cx0, cy0 = cx1, cy1
+	}
+	return tangent{
+		cx:  cx0,
+		cy:  cy0,
+		x01: -ox,
+		y01: -oy,
+		x11: cx0 * (r1/r - 1),
+		y11: cy0 * (r1/r - 1),
+	}
+}
+
+func intersect(x0, y0, x1, y1, x2, y2, x3, y3 float64) (float64, float64, bool) {
+	var (
+		x10 = x1 - x0
+		y10 = y1 - y0
+		x32 = x3 - x2
+		y32 = y3 - y2
+		t   = y32*x10 - x32*y10
+	)
+	if t*t < epsilon {
+		return 0, 0, false
+	}
+	t = (x32*(y0-y2) - y32*(x0-x2)) / t
+	return x0 + t*x10, y0 + t*y10, true
+}
+
+func asin(x float64) float64 {
+	if x >= 1 {
+		return math.Pi / 2
+	}
+	if x <= -1 {
+		return -math.Pi / 2
+	}
+	return math.Asin(x)
+}
@@ -0,0 +1,113 @@
+package chart
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const defaultAreaOpacity = 0.7
+
+type AreaChart struct {
+	Chart
+	Categories []string
+	Series     []Series
+	Stacked    bool
+	Opacity    float64
+}
+
+func (c AreaChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c AreaChart) Element() svg.Element {
+	var (
+		canvas       = c.canvas()
+		plot         = c.plot()
+		_, dim       = c.area()
+		lower, upper = c.bounds()
+		x            = scale.NewPoint(c.Categories, scale.NewRange(0, dim.W))
+		y            = scale.NewLinear(c.domain(lower, upper), scale.NewRange(dim.H, 0)).Nice(defaultTicks)
+	)
+	axes := c.axes(x, y, dim)
+	for _, a := range axes {
+		plot.Append(a.Element())
+	}
+	for i := len(c.Series) - 1; i >= 0; i-- {
+		var (
+			s    = c.Series[i]
+			path svg.Path
+		)
+		for j, cat := range c.Categories {
+			pos := svg.NewPos(x.Scale(cat), y.Scale(upper[i][j]))
+			if j == 0 {
+				path.AbsMoveTo(pos)
+			} else {
+				path.AbsLineTo(pos)
+			}
+		}
+		for j := len(c.Categories) - 1; j >= 0; j-- {
+			path.AbsLineTo(svg.NewPos(x.Scale(c.Categories[j]), y.Scale(lower[i][j])))
+		}
+		path.ClosePath()
+		path.Class = append(path.Class, "area")
+		path.Fill = svg.NewFill(c.color(i, s))
+		path.Fill.Opacity = c.opacity()
+		path.Stroke = svg.NewStroke(c.color(i, s), 1)
+		path.Data = []svg.Datum{
+			seriesData(s),
+			{Name: "values", Value: joinValues(s.Values)},
+		}
+		plot.Append(path.AsElement())
+	}
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(c.entries(c.Series, ShapeRect), dim, axes...))
+	return canvas.AsElement()
+}
+
+func (c AreaChart) bounds() ([][]float64, [][]float64) {
+	if c.Stacked {
+		return stack(c.Categories, c.Series)
+	}
+	var (
+		lower = make([][]float64, len(c.Series))
+		upper = make([][]float64, len(c.Series))
+	)
+	for i, s := range c.Series {
+		lower[i] = make([]float64, len(c.Categories))
+		upper[i] = make([]float64, len(c.Categories))
+		for j := range c.Categories {
+			if j < len(s.Values) {
+				upper[i][j] = s.Values[j]
+			}
+		}
+	}
+	return lower, upper
+}
+
+func (c AreaChart) domain(lower, upper [][]float64) scale.Range {
+	values := []float64{0}
+	for i := range lower {
+		values = append(values, lower[i]...)
+		values = append(values, upper[i]...)
+	}
+	return scale.NewRange(extent(values...))
+}
+
+func joinValues(values []float64) string {
+	list := make([]string, len(values))
+	for i := range values {
+		list[i] = strconv.FormatFloat(values[i], 'f', -1, 64)
+	}
+	return strings.Join(list, ",")
+}
+
+func (c AreaChart) opacity() float64 {
+	if c.Opacity <= 0 {
+		return defaultAreaOpacity
+	}
+	return c.Opacity
+}
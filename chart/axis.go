@@ -0,0 +1,425 @@
+package chart
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	defaultTickSize    = 6
+	defaultTickPadding = 3
+	defaultLabelGap    = 2
+)
+
+type Orientation int
+
+const (
+	Bottom Orientation = iota
+	Top
+	Left
+	Right
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case Bottom:
+		return "bottom"
+	case Top:
+		return "top"
+	case Left:
+		return "left"
+	case Right:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+func (o Orientation) Horizontal() bool {
+	return o == Bottom || o == Top
+}
+
+type Collision int
+
+const (
+	CollideHide Collision = iota
+	CollideRotate
+	CollideKeep
+)
+
+type Axis struct {
+	Scale  scale.Scale
+	Orient Orientation
+	Count  int
+	Values []float64
+	Format func(float64) string
+
+	svg.Pos
+	TickSize    float64
+	OuterSize   float64
+	TickPadding float64
+	Rotate      float64
+	Grid        float64
+	Collision   Collision
+	Title       string
+
+	Font       svg.Font
+	Stroke     svg.Stroke
+	GridStroke svg.Stroke
+	Measure    func(string, svg.Font) float64
+	OmitDomain bool
+	OmitLabels bool
+}
+
+func NewAxis(s scale.Scale, orient Orientation) Axis {
+	return Axis{
+		Scale:       s,
+		Orient:      orient,
+		TickSize:    defaultTickSize,
+		OuterSize:   defaultTickSize,
+		TickPadding: defaultTickPadding,
+	}
+}
+
+func (a Axis) Element() svg.Element {
+	g := a.Group()
+	return g.AsElement()
+}
+
+func (a Axis) Group() svg.Group {
+	var (
+		grp   svg.Group
+		ticks = a.Ticks()
+		font  = a.Font.OrDefault()
+	)
+	grp.Class = append(grp.Class, "axis", a.Orient.String())
+	grp.Transform = svg.Translate(a.X, a.Y)
+	if a.Grid != 0 {
+		grid := a.gridlines(ticks)
+		grp.Append(grid.AsElement())
+	}
+	if !a.OmitDomain {
+		dom := a.domain()
+		grp.Append(dom.AsElement())
+	}
+	var (
+		rotate  = a.rotation(ticks, font)
+		visible = a.visible(ticks, font, rotate)
+	)
+	for i, t := range ticks {
+		var (
+			tick svg.Group
+			line = a.tickLine()
+		)
+		tick.Class = append(tick.Class, "tick")
+		if a.Orient.Horizontal() {
+			tick.Transform = svg.Translate(t.Offset, 0)
+		} else {
+			tick.Transform = svg.Translate(0, t.Offset)
+		}
+		tick.Append(line.AsElement())
+		if !a.OmitLabels && visible[i] {
+			text := a.label(t.Label, font, rotate)
+			tick.Append(text.AsElement())
+		}
+		grp.Append(tick.AsElement())
+	}
+	if a.Title != "" {
+		title := a.title(font)
+		grp.Append(title.AsElement())
+	}
+	return grp
+}
+
+type valuer interface {
+	Values(int) []float64
+}
+
+func (a Axis) Depth() float64 {
+	var (
+		ticks  = a.Ticks()
+		font   = a.Font.OrDefault()
+		rotate = a.rotation(ticks, font)
+		dist   = math.Max(a.TickSize, 0) + a.TickPadding
+		depth  = math.Max(math.Max(a.TickSize, a.OuterSize), 0)
+	)
+	if !a.OmitLabels {
+		var (
+			sin, cos = math.Sincos(rotate * math.Pi / 180)
+			visible  = a.visible(ticks, font, rotate)
+			size     float64
+		)
+		sin, cos = math.Abs(sin), math.Abs(cos)
+		for i, t := range ticks {
+			if !visible[i] {
+				continue
+			}
+			width := a.measure(t.Label, font)
+			if a.Orient.Horizontal() {
+				size = math.Max(size, width*sin+font.Size*cos)
+			} else {
+				size = math.Max(size, width*cos+font.Size*sin)
+			}
+		}
+		depth = math.Max(depth, dist+size)
+	}
+	if a.Title != "" {
+		if a.Orient.Horizontal() {
+			dist += font.Size * 2.5
+		} else {
+			dist += a.labelWidth(font) + font.Size
+		}
+		depth = math.Max(depth, dist+font.Size)
+	}
+	return depth
+}
+
+func (a Axis) Ticks() []scale.Tick {
+	c, ok := a.Scale.(scale.Continuous)
+	if !ok || (len(a.Values) == 0 && a.Format == nil) {
+		return a.Scale.Ticks(a.Count)
+	}
+	values := a.Values
+	if len(values) == 0 {
+		v, ok := a.Scale.(valuer)
+		if !ok {
+			return a.Scale.Ticks(a.Count)
+		}
+		values = v.Values(a.Count)
+	}
+	format := a.Format
+	if format == nil {
+		format = func(v float64) string {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	ticks := make([]scale.Tick, 0, len(values))
+	for _, v := range values {
+		t := scale.Tick{
+			Offset: c.Scale(v),
+			Label:  format(v),
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+func (a Axis) domain() svg.PolyLine {
+	var (
+		rg    = a.Scale.Extent()
+		outer = a.OuterSize * a.sign()
+		line  svg.PolyLine
+	)
+	if a.Orient.Horizontal() {
+		line.Points = []svg.Pos{
+			svg.NewPos(rg.Starts, outer),
+			svg.NewPos(rg.Starts, 0),
+			svg.NewPos(rg.Ends, 0),
+			svg.NewPos(rg.Ends, outer),
+		}
+	} else {
+		line.Points = []svg.Pos{
+			svg.NewPos(outer, rg.Starts),
+			svg.NewPos(0, rg.Starts),
+			svg.NewPos(0, rg.Ends),
+			svg.NewPos(outer, rg.Ends),
+		}
+	}
+	if outer == 0 {
+		line.Points = line.Points[1:3]
+	}
+	line.Class = append(line.Class, "domain")
+	line.Stroke = a.stroke()
+	line.Fill = svg.NewFill("none")
+	return line
+}
+
+func (a Axis) tickLine() svg.Line {
+	var (
+		size = a.TickSize * a.sign()
+		line svg.Line
+	)
+	if a.Orient.Horizontal() {
+		line = svg.NewLine(svg.NewPos(0, 0), svg.NewPos(0, size))
+	} else {
+		line = svg.NewLine(svg.NewPos(0, 0), svg.NewPos(size, 0))
+	}
+	line.Stroke = a.stroke()
+	return line
+}
+
+func (a Axis) gridlines(ticks []scale.Tick) svg.Group {
+	var (
+		grp    svg.Group
+		length = -a.Grid * a.sign()
+		stroke = a.GridStroke
+	)
+	if stroke.IsZero() {
+		stroke = svg.NewStroke("lightgray", 1)
+	}
+	grp.Class = append(grp.Class, "grid")
+	for _, t := range ticks {
+		var line svg.Line
+		if a.Orient.Horizontal() {
+			line = svg.NewLine(svg.NewPos(t.Offset, 0), svg.NewPos(t.Offset, length))
+		} else {
+			line = svg.NewLine(svg.NewPos(0, t.Offset), svg.NewPos(length, t.Offset))
+		}
+		line.Stroke = stroke
+		grp.Append(line.AsElement())
+	}
+	return grp
+}
+
+func (a Axis) label(str string, font svg.Font, rotate float64) svg.Text {
+	var (
+		text = svg.NewText(str)
+		dist = (math.Max(a.TickSize, 0) + a.TickPadding) * a.sign()
+	)
+	text.Font = font
+	switch a.Orient {
+	case Bottom:
+		text.Pos = svg.NewPos(0, dist)
+		text.Anchor = svg.AlignMiddle
+		text.Baseline = "hanging"
+	case Top:
+		text.Pos = svg.NewPos(0, dist)
+		text.Anchor = svg.AlignMiddle
+	case Left:
+		text.Pos = svg.NewPos(dist, 0)
+		text.Anchor = svg.AlignEnd
+		text.Baseline = "middle"
+	case Right:
+		text.Pos = svg.NewPos(dist, 0)
+		text.Anchor = svg.AlignStart
+		text.Baseline = "middle"
+	}
+	if rotate != 0 {
+		text.Transform.Rotate(rotate, text.Pos.X, text.Pos.Y)
+		if a.Orient.Horizontal() {
+			if (rotate < 0) == (a.Orient == Bottom) {
+				text.Anchor = svg.AlignEnd
+			} else {
+				text.Anchor = svg.AlignStart
+			}
+			text.Baseline = "middle"
+		}
+	}
+	return text
+}
+
+func (a Axis) title(font svg.Font) svg.Text {
+	var (
+		rg   = a.Scale.Extent()
+		mid  = (rg.Starts + rg.Ends) / 2
+		text = svg.NewText(a.Title)
+		dist = (math.Max(a.TickSize, 0) + a.TickPadding + font.Size*2.5) * a.sign()
+	)
+	text.Font = font
+	text.Anchor = svg.AlignMiddle
+	text.Class = append(text.Class, "title")
+	if a.Orient.Horizontal() {
+		text.Pos = svg.NewPos(mid, dist)
+		if a.Orient == Bottom {
+			text.Baseline = "hanging"
+		}
+	} else {
+		dist = (math.Max(a.TickSize, 0) + a.TickPadding + a.labelWidth(font) + font.Size) * a.sign()
+		text.Pos = svg.NewPos(dist, mid)
+		text.Transform.Rotate(90*a.sign(), dist, mid)
+	}
+	return text
+}
+
+func (a Axis) rotation(ticks []scale.Tick, font svg.Font) float64 {
+	if a.Collision == CollideRotate && a.Orient.Horizontal() && a.Rotate == 0 && a.collide(ticks, font, 0) {
+		return -45
+	}
+	return a.Rotate
+}
+
+func (a Axis) visible(ticks []scale.Tick, font svg.Font, rotate float64) []bool {
+	list := make([]bool, len(ticks))
+	if a.Collision == CollideKeep {
+		for i := range list {
+			list[i] = true
+		}
+		return list
+	}
+	var (
+		last    = math.Inf(-1)
+		reverse = len(ticks) > 1 && ticks[0].Offset > ticks[len(ticks)-1].Offset
+	)
+	for j := range ticks {
+		i := j
+		if reverse {
+			i = len(ticks) - 1 - j
+		}
+		lo, hi := a.extent(ticks[i], font, rotate)
+		if lo >= last {
+			list[i] = true
+			last = hi + defaultLabelGap
+		}
+	}
+	return list
+}
+
+func (a Axis) collide(ticks []scale.Tick, font svg.Font, rotate float64) bool {
+	for _, v := range a.visible(ticks, font, rotate) {
+		if !v {
+			return true
+		}
+	}
+	return false
+}
+
+func (a Axis) extent(t scale.Tick, font svg.Font, rotate float64) (float64, float64) {
+	var (
+		width    = a.measure(t.Label, font)
+		height   = font.Size
+		sin, cos = math.Sincos(rotate * math.Pi / 180)
+		size     float64
+	)
+	sin, cos = math.Abs(sin), math.Abs(cos)
+	if a.Orient.Horizontal() {
+		size = width*cos + height*sin
+		if sin > 0 {
+			size = math.Min(size, height/sin)
+		}
+	} else {
+		size = width*sin + height*cos
+	}
+	return t.Offset - size/2, t.Offset + size/2
+}
+
+func (a Axis) labelWidth(font svg.Font) float64 {
+	var width float64
+	for _, t := range a.Ticks() {
+		width = math.Max(width, a.measure(t.Label, font))
+	}
+	return width
+}
+
+func (a Axis) measure(str string, font svg.Font) float64 {
+	if a.Measure != nil {
+		return a.Measure(str, font)
+	}
+	return svg.EstimateWidth(str, font)
+}
+
+func (a Axis) stroke() svg.Stroke {
+	if a.Stroke.IsZero() {
+		return svg.DefaultStroke
+	}
+	return a.Stroke
+}
+
+func (a Axis) sign() float64 {
+	if a.Orient == Top || a.Orient == Left {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,120 @@
+package chart
+
+import (
+	"io"
+	"math"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const defaultBarPadding = 0.2
+
+type BarChart struct {
+	Chart
+	Categories []string
+	Series     []Series
+	Stacked    bool
+	BarPadding float64
+}
+
+func (c BarChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c BarChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		x      = scale.NewBand(c.Categories, scale.NewRange(0, dim.W))
+		y      = scale.NewLinear(c.domain(), scale.NewRange(dim.H, 0)).Nice(defaultTicks)
+	)
+	x.PaddingInner = c.barPadding()
+	x.PaddingOuter = c.barPadding() / 2
+	axes := c.axes(x, y, dim)
+	for _, a := range axes {
+		plot.Append(a.Element())
+	}
+	if c.Stacked {
+		lower, upper := stack(c.Categories, c.Series)
+		for i, s := range c.Series {
+			for j, cat := range c.Categories {
+				bar := c.bar(s, i, j, x.Scale(cat), x.Bandwidth(), y.Scale(upper[i][j]), y.Scale(lower[i][j]))
+				plot.Append(bar.AsElement())
+			}
+		}
+	} else {
+		var (
+			names = make([]string, len(c.Series))
+			inner scale.Band
+		)
+		for i := range c.Series {
+			names[i] = c.Series[i].Name
+		}
+		inner = scale.NewBand(names, scale.NewRange(0, x.Bandwidth()))
+		inner.PaddingInner = c.barPadding() / 2
+		for i, s := range c.Series {
+			for j, cat := range c.Categories {
+				var v float64
+				if j < len(s.Values) {
+					v = s.Values[j]
+				}
+				var (
+					pos = x.Scale(cat) + inner.Scale(s.Name)
+					top = y.Scale(math.Max(v, 0))
+					bot = y.Scale(math.Min(v, 0))
+				)
+				bar := c.bar(s, i, j, pos, inner.Bandwidth(), top, bot)
+				plot.Append(bar.AsElement())
+			}
+		}
+	}
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(c.entries(c.Series, ShapeRect), dim, axes...))
+	return canvas.AsElement()
+}
+
+func (c BarChart) bar(s Series, i, j int, x, width, top, bottom float64) svg.Rect {
+	var (
+		rect svg.Rect
+		v    float64
+	)
+	if j < len(s.Values) {
+		v = s.Values[j]
+	}
+	rect.Class = append(rect.Class, "bar")
+	rect.Pos = svg.NewPos(x, top)
+	rect.Dim = svg.NewDim(width, bottom-top)
+	rect.Fill = svg.NewFill(c.color(i, s))
+	rect.Data = []svg.Datum{
+		seriesData(s),
+		{Name: "category", Value: c.Categories[j]},
+		{Name: "value", Value: v},
+	}
+	return rect
+}
+
+func (c BarChart) domain() scale.Range {
+	var values []float64
+	if c.Stacked {
+		lower, upper := stack(c.Categories, c.Series)
+		for i := range lower {
+			values = append(values, lower[i]...)
+			values = append(values, upper[i]...)
+		}
+	} else {
+		for _, s := range c.Series {
+			values = append(values, s.Values...)
+		}
+	}
+	values = append(values, 0)
+	return scale.NewRange(extent(values...))
+}
+
+func (c BarChart) barPadding() float64 {
+	if c.BarPadding <= 0 {
+		return defaultBarPadding
+	}
+	return c.BarPadding
+}
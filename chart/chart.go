@@ -0,0 +1,310 @@
+package chart
+
+import (
+	"bufio"
+	"io"
+	"math"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/layout"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	defaultWidth     = 800
+	defaultHeight    = 600
+	defaultTitleSize = 16
+	defaultTicks     = 8
+	swatchSize       = 12
+	rampSamples      = 9
+)
+
+var Palette = []string{
+	"#1f77b4",
+	"#ff7f0e",
+	"#2ca02c",
+	"#d62728",
+	"#9467bd",
+	"#8c564b",
+	"#e377c2",
+	"#7f7f7f",
+	"#bcbd22",
+	"#17becf",
+}
+
+var defaultPadding = layout.Padding{
+	Top:    40,
+	Right:  140,
+	Bottom: 50,
+	Left:   60,
+}
+
+type Point struct {
+	X     float64
+	Y     float64
+	Size  float64
+	Label string
+}
+
+type Series struct {
+	Name   string
+	Color  string
+	Values []float64
+	Points []Point
+}
+
+type Chart struct {
+	Title      string
+	XTitle     string
+	YTitle     string
+	Width      float64
+	Height     float64
+	Padding    layout.Padding
+	Colors     []string
+	Font       svg.Font
+	Grid       bool
+	Legend     Placement
+	OmitLegend bool
+}
+
+func (c Chart) canvas() svg.SVG {
+	var (
+		width, height = c.size()
+		s             = svg.NewSVG()
+	)
+	s.Dim = svg.NewDim(width, height)
+	if c.Title != "" {
+		var (
+			font = c.Font.OrDefault()
+			text = svg.NewText(c.Title)
+		)
+		font.Size = defaultTitleSize
+		text.Font = font
+		text.Pos = svg.NewPos(width/2, c.titleBand()/2)
+		text.Anchor = svg.AlignMiddle
+		text.Baseline = "middle"
+		text.Class = append(text.Class, "title")
+		s.Append(text.AsElement())
+	}
+	return s
+}
+
+func (c Chart) area() (svg.Pos, svg.Dim) {
+	var (
+		width, height = c.size()
+		pad           = c.padding()
+		pos           = svg.NewPos(pad.Left, pad.Top)
+		dim           = svg.NewDim(width-pad.Left-pad.Right, height-pad.Top-pad.Bottom)
+	)
+	return pos, dim
+}
+
+func (c Chart) plot() svg.Group {
+	var (
+		pos, _ = c.area()
+		grp    svg.Group
+	)
+	grp.Class = append(grp.Class, "plot")
+	grp.Transform = svg.Translate(pos.X, pos.Y)
+	return grp
+}
+
+func (c Chart) axes(x, y scale.Scale, dim svg.Dim) []Axis {
+	var (
+		ax = NewAxis(x, Bottom)
+		ay = NewAxis(y, Left)
+	)
+	ax.Pos = svg.NewPos(0, dim.H)
+	ax.Title = c.XTitle
+	ax.Count = defaultTicks
+	ax.Font = c.Font.OrDefault()
+	ax.Collision = CollideRotate
+	ay.Title = c.YTitle
+	ay.Count = defaultTicks
+	ay.Font = c.Font.OrDefault()
+	if c.Grid {
+		ay.Grid = dim.W
+	}
+	return []Axis{ax, ay}
+}
+
+func (c Chart) legend(entries []Entry, dim svg.Dim, axes ...Axis) svg.Element {
+	if c.OmitLegend || len(entries) == 0 {
+		return nil
+	}
+	return c.placeLegend(Legend{Entries: entries}, dim, axes)
+}
+
+func (c Chart) ramp(s scale.ColorScale, dim svg.Dim, axes ...Axis) svg.Element {
+	if c.OmitLegend || s == nil {
+		return nil
+	}
+	leg := Legend{
+		Ramp: &Ramp{
+			Domain: s.Extent(),
+			Colors: scale.Sample(s, rampSamples),
+		},
+	}
+	return c.placeLegend(leg, dim, axes)
+}
+
+func (c Chart) placeLegend(leg Legend, dim svg.Dim, axes []Axis) svg.Element {
+	pos, _ := c.area()
+	leg.Font = c.Font.OrDefault()
+	leg.Margin = swatchSize
+	switch c.Legend {
+	case PlaceTop, PlaceBottom:
+		leg.Horizontal = true
+		leg.Width = dim.W
+	default:
+		leg.Height = dim.H
+	}
+	if leg.Ramp != nil {
+		leg.Ramp.Length = math.Min(leg.Height, defaultRampLength)
+		if leg.Horizontal {
+			leg.Ramp.Length = math.Min(leg.Width, defaultRampLength)
+		}
+	}
+	switch c.Legend {
+	case PlaceTop:
+		depth := axisDepth(axes, Top)
+		pos.Y -= depth
+		dim.H += depth
+	case PlaceBottom:
+		dim.H += axisDepth(axes, Bottom)
+	}
+	leg.Place(pos, dim, c.Legend)
+	if c.Legend == PlaceTop && c.Title != "" {
+		leg.Pos.Y = math.Max(leg.Pos.Y, (c.titleBand()+defaultTitleSize)/2)
+	}
+	return leg.Element()
+}
+
+func axisDepth(axes []Axis, orient Orientation) float64 {
+	var depth float64
+	for _, a := range axes {
+		if a.Orient == orient {
+			depth = math.Max(depth, a.Depth())
+		}
+	}
+	return depth
+}
+
+func (c Chart) entries(series []Series, shape Shape) []Entry {
+	list := make([]Entry, 0, len(series))
+	for i, s := range series {
+		e := Entry{
+			Label: s.Name,
+			Color: c.color(i, s),
+			Shape: shape,
+		}
+		list = append(list, e)
+	}
+	return list
+}
+
+func (c Chart) color(i int, s Series) string {
+	if s.Color != "" {
+		return s.Color
+	}
+	colors := c.Colors
+	if len(colors) == 0 {
+		colors = Palette
+	}
+	return colors[i%len(colors)]
+}
+
+func (c Chart) size() (float64, float64) {
+	width, height := c.Width, c.Height
+	if width <= 0 {
+		width = defaultWidth
+	}
+	if height <= 0 {
+		height = defaultHeight
+	}
+	return width, height
+}
+
+func (c Chart) padding() layout.Padding {
+	if c.Padding != (layout.Padding{}) {
+		return c.Padding
+	}
+	pad := defaultPadding
+	if !c.OmitLegend {
+		band := math.Max(swatchSize, c.Font.OrDefault().Size) + swatchSize
+		switch c.Legend {
+		case PlaceTop:
+			pad.Top += band
+		case PlaceBottom:
+			pad.Bottom += band
+		}
+	}
+	return pad
+}
+
+func (c Chart) titleBand() float64 {
+	if c.Padding != (layout.Padding{}) {
+		return c.Padding.Top
+	}
+	return defaultPadding.Top
+}
+
+func render(w io.Writer, el svg.Element) error {
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el.Render(ws)
+	return nil
+}
+
+func seriesData(s Series) svg.Datum {
+	return svg.Datum{Name: "series", Value: s.Name}
+}
+
+func extent(values ...float64) (float64, float64) {
+	var (
+		min = math.Inf(1)
+		max = math.Inf(-1)
+	)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return 0, 1
+	}
+	if min == max {
+		return min - 1, max + 1
+	}
+	return min, max
+}
+
+func stack(categories []string, series []Series) ([][]float64, [][]float64) {
+	var (
+		lower = make([][]float64, len(series))
+		upper = make([][]float64, len(series))
+		pos   = make([]float64, len(categories))
+		neg   = make([]float64, len(categories))
+	)
+	for i, s := range series {
+		lower[i] = make([]float64, len(categories))
+		upper[i] = make([]float64, len(categories))
+		for j := range categories {
+			var v float64
+			if j < len(s.Values) {
+				v = s.Values[j]
+			}
+			if v >= 0 {
+				lower[i][j], upper[i][j] = pos[j], pos[j]+v
+				pos[j] += v
+			} else {
+				lower[i][j], upper[i][j] = neg[j]+v, neg[j]
+				neg[j] += v
+			}
+		}
+	}
+	return lower, upper
+}
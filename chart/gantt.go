@@ -0,0 +1,391 @@
+package chart
+
+import (
+	"io"
+	"math"
+	"time"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	ganttArrowId   = "gantt-arrow"
+	ganttTickWidth = 80
+	ganttRatio     = 0.6
+	ganttElbow     = 8
+	ganttHeader    = "#f2f2f2"
+	ganttToday     = "#d62728"
+)
+
+type Task struct {
+	Id        string
+	Name      string
+	Group     string
+	Starts    time.Time
+	Ends      time.Time
+	Progress  float64
+	Depends   []string
+	Milestone bool
+	Color     string
+}
+
+func (t Task) key() string {
+	if t.Id != "" {
+		return t.Id
+	}
+	return t.Name
+}
+
+func (t Task) milestone() bool {
+	return t.Milestone || t.Ends.IsZero() || !t.Ends.After(t.Starts)
+}
+
+type GanttChart struct {
+	Chart
+	Tasks  []Task
+	Today  time.Time
+	Ticks  int
+	Ratio  float64
+	Format string
+}
+
+type ganttRow struct {
+	Group string
+	Task  int
+}
+
+func (c GanttChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c GanttChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		font   = c.Font.OrDefault()
+	)
+	if len(c.Tasks) == 0 {
+		return canvas.AsElement()
+	}
+	var (
+		rows, groups = c.rows()
+		height       = dim.H / float64(len(rows))
+		count        = c.count(dim)
+		starts, ends = c.extent()
+		ts           = scale.NewTime(starts, ends, scale.NewRange(0, dim.W)).Nice(count)
+		index        = make(map[string]int)
+		bands        svg.Group
+		labels       svg.Group
+		links        svg.Group
+		tasks        svg.Group
+	)
+	ts.Format = c.Format
+
+	axis := NewAxis(ts, Top)
+	axis.Count = count
+	axis.Font = font
+	axis.Collision = CollideRotate
+	if c.Grid {
+		axis.Grid = dim.H
+	}
+	plot.Append(axis.Element())
+
+	bands.Class = append(bands.Class, "groups")
+	labels.Class = append(labels.Class, "labels")
+	links.Class = append(links.Class, "dependencies")
+	tasks.Class = append(tasks.Class, "tasks")
+	for i, r := range rows {
+		y := float64(i) * height
+		if r.Task < 0 {
+			var (
+				rect svg.Rect
+				text = svg.NewText(r.Group)
+			)
+			rect.Pos = svg.NewPos(0, y)
+			rect.Dim = svg.NewDim(dim.W, height)
+			rect.Fill = svg.NewFill(ganttHeader)
+			rect.Class = append(rect.Class, "group")
+			rect.Data = []svg.Datum{{Name: "group", Value: r.Group}}
+			bands.Append(rect.AsElement())
+
+			text.Font = font
+			text.Font.Weight = "bold"
+			text.Pos = svg.NewPos(-defaultTickPadding, y+height/2)
+			text.Anchor = svg.AlignEnd
+			text.Baseline = "middle"
+			text.Class = append(text.Class, "group")
+			labels.Append(text.AsElement())
+			continue
+		}
+		index[c.Tasks[r.Task].key()] = i
+
+		text := svg.NewText(c.Tasks[r.Task].Name)
+		text.Font = font
+		text.Pos = svg.NewPos(-defaultTickPadding, y+height/2)
+		text.Anchor = svg.AlignEnd
+		text.Baseline = "middle"
+		text.Class = append(text.Class, "task")
+		labels.Append(text.AsElement())
+	}
+	for i, r := range rows {
+		if r.Task < 0 {
+			continue
+		}
+		var (
+			t     = c.Tasks[r.Task]
+			color = c.color(groups[t.Group], Series{Color: t.Color})
+		)
+		tasks.Append(c.task(t, ts, float64(i)*height, height, color))
+		for _, dep := range t.Depends {
+			j, ok := index[dep]
+			if !ok {
+				continue
+			}
+			src := c.Tasks[rows[j].Task]
+			links.Append(c.dependency(src, t, ts, j, i, height))
+		}
+	}
+	canvas.Append(c.defs())
+	plot.Append(bands.AsElement())
+	plot.Append(labels.AsElement())
+	plot.Append(tasks.AsElement())
+	plot.Append(links.AsElement())
+	if e := c.today(ts, dim, font); e != nil {
+		plot.Append(e)
+	}
+	canvas.Append(plot.AsElement())
+	if len(groups) > 1 {
+		canvas.Append(c.legend(c.groupEntries(), dim, axis))
+	}
+	return canvas.AsElement()
+}
+
+func (c GanttChart) task(t Task, ts scale.Time, y, height float64, color string) svg.Element {
+	var (
+		grp svg.Group
+		bar = height * c.ratio()
+		top = y + (height-bar)/2
+		x   = ts.Scale(t.Starts)
+	)
+	grp.Class = append(grp.Class, "task")
+	grp.Data = []svg.Datum{
+		{Name: "id", Value: t.key()},
+		{Name: "name", Value: t.Name},
+		{Name: "starts", Value: t.Starts.Format(time.RFC3339)},
+	}
+	if t.Group != "" {
+		grp.Data = append(grp.Data, svg.Datum{Name: "group", Value: t.Group})
+	}
+	if t.milestone() {
+		var (
+			path svg.Path
+			mid  = y + height/2
+			half = bar / 2
+		)
+		grp.Class = append(grp.Class, "milestone")
+		path.AbsMoveTo(svg.NewPos(x, mid-half))
+		path.AbsLineTo(svg.NewPos(x+half, mid))
+		path.AbsLineTo(svg.NewPos(x, mid+half))
+		path.AbsLineTo(svg.NewPos(x-half, mid))
+		path.ClosePath()
+		path.Fill = svg.NewFill(color)
+		grp.Append(path.AsElement())
+		return grp.AsElement()
+	}
+	grp.Data = append(grp.Data, svg.Datum{Name: "ends", Value: t.Ends.Format(time.RFC3339)})
+
+	var (
+		rect  svg.Rect
+		width = math.Max(0, ts.Scale(t.Ends)-x)
+	)
+	rect.Pos = svg.NewPos(x, top)
+	rect.Dim = svg.NewDim(width, bar)
+	rect.Fill = svg.NewFill(color)
+	rect.Class = append(rect.Class, "bar")
+	grp.Append(rect.AsElement())
+	if t.Progress > 0 {
+		var (
+			done     svg.Rect
+			progress = math.Min(t.Progress, 1)
+		)
+		done.Pos = svg.NewPos(x, top+bar/3)
+		done.Dim = svg.NewDim(width*progress, bar/3)
+		done.Fill = svg.NewFill("#000000")
+		done.Fill.Opacity = 0.3
+		done.Class = append(done.Class, "progress")
+		grp.Data = append(grp.Data, svg.Datum{Name: "progress", Value: progress})
+		grp.Append(done.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (c GanttChart) dependency(src, dst Task, ts scale.Time, from, to int, height float64) svg.Element {
+	var (
+		path svg.Path
+		half = height * c.ratio() / 2
+		x1   = ts.Scale(src.Ends)
+		x2   = ts.Scale(dst.Starts)
+		y1   = (float64(from) + 0.5) * height
+		y2   = (float64(to) + 0.5) * height
+	)
+	if src.milestone() {
+		x1 = ts.Scale(src.Starts) + half
+	}
+	if dst.milestone() {
+		x2 -= half
+	}
+	path.AbsMoveTo(svg.NewPos(x1, y1))
+	if x2-x1 >= 2*ganttElbow {
+		path.AbsHorizontalLine(x1 + ganttElbow)
+		path.AbsVerticalLine(y2)
+	} else {
+		mid := y1 + height/2
+		if y2 < y1 {
+			mid = y1 - height/2
+		}
+		path.AbsHorizontalLine(x1 + ganttElbow)
+		path.AbsVerticalLine(mid)
+		path.AbsHorizontalLine(x2 - ganttElbow)
+		path.AbsVerticalLine(y2)
+	}
+	path.AbsHorizontalLine(x2)
+	path.Class = append(path.Class, "dependency")
+	path.Fill = svg.NewFill("none")
+	path.Stroke = svg.NewStroke("#555555", 1)
+	path.Markers.End = ganttArrowId
+	path.Data = []svg.Datum{
+		{Name: "source", Value: src.key()},
+		{Name: "target", Value: dst.key()},
+	}
+	return path.AsElement()
+}
+
+func (c GanttChart) today(ts scale.Time, dim svg.Dim, font svg.Font) svg.Element {
+	if c.Today.IsZero() || c.Today.Before(ts.Starts) || c.Today.After(ts.Ends) {
+		return nil
+	}
+	var (
+		grp  svg.Group
+		x    = ts.Scale(c.Today)
+		line = svg.NewLine(svg.NewPos(x, 0), svg.NewPos(x, dim.H))
+		text = svg.NewText("today")
+	)
+	grp.Class = append(grp.Class, "today")
+	grp.Data = []svg.Datum{{Name: "date", Value: c.Today.Format(time.RFC3339)}}
+	line.Stroke = svg.NewStroke(ganttToday, 1)
+	line.Stroke.DashArray = []int{4, 2}
+	text.Font = font
+	text.Font.Fill = ganttToday
+	text.Pos = svg.NewPos(x, dim.H+font.Size+defaultTickPadding)
+	text.Anchor = svg.AlignMiddle
+	grp.Append(line.AsElement())
+	grp.Append(text.AsElement())
+	return grp.AsElement()
+}
+
+func (c GanttChart) defs() svg.Element {
+	var (
+		defs   svg.Defs
+		marker = svg.NewMarker(ganttArrowId, 6, 6)
+		arrow  svg.Path
+	)
+	marker.ViewBox.Dim = svg.NewDim(10, 10)
+	marker.RefX = 10
+	marker.RefY = 5
+	arrow.AbsMoveTo(svg.NewPos(0, 0))
+	arrow.AbsLineTo(svg.NewPos(10, 5))
+	arrow.AbsLineTo(svg.NewPos(0, 10))
+	arrow.ClosePath()
+	arrow.Fill = svg.NewFill("#555555")
+	marker.Append(arrow.AsElement())
+	defs.Append(marker.AsElement())
+	return defs.AsElement()
+}
+
+func (c GanttChart) rows() ([]ganttRow, map[string]int) {
+	var (
+		groups = make(map[string]int)
+		order  []string
+		named  bool
+	)
+	for _, t := range c.Tasks {
+		if _, ok := groups[t.Group]; ok {
+			continue
+		}
+		groups[t.Group] = len(order)
+		order = append(order, t.Group)
+		named = named || t.Group != ""
+	}
+	var rows []ganttRow
+	for _, g := range order {
+		if named && g != "" {
+			rows = append(rows, ganttRow{Group: g, Task: -1})
+		}
+		for i, t := range c.Tasks {
+			if t.Group == g {
+				rows = append(rows, ganttRow{Group: g, Task: i})
+			}
+		}
+	}
+	return rows, groups
+}
+
+func (c GanttChart) groupEntries() []Entry {
+	var (
+		list []Entry
+		seen = make(map[string]bool)
+	)
+	for _, t := range c.Tasks {
+		if seen[t.Group] {
+			continue
+		}
+		seen[t.Group] = true
+		e := Entry{
+			Label: t.Group,
+			Color: c.color(len(list), Series{}),
+			Shape: ShapeRect,
+		}
+		list = append(list, e)
+	}
+	return list
+}
+
+func (c GanttChart) extent() (time.Time, time.Time) {
+	var starts, ends time.Time
+	for i, t := range c.Tasks {
+		last := t.Ends
+		if t.milestone() {
+			last = t.Starts
+		}
+		if i == 0 || t.Starts.Before(starts) {
+			starts = t.Starts
+		}
+		if i == 0 || last.After(ends) {
+			ends = last
+		}
+	}
+	if !ends.After(starts) {
+		ends = starts.Add(24 * time.Hour)
+	}
+	return starts, ends
+}
+
+func (c GanttChart) count(dim svg.Dim) int {
+	if c.Ticks > 0 {
+		return c.Ticks
+	}
+	n := int(dim.W / ganttTickWidth)
+	if n < 2 {
+		n = 2
+	}
+	return n
+}
+
+func (c GanttChart) ratio() float64 {
+	if c.Ratio <= 0 || c.Ratio > 1 {
+		return ganttRatio
+	}
+	return c.Ratio
+}
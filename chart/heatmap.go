@@ -0,0 +1,290 @@
+package chart
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	emptyCell      = "#ebedf0"
+	dateLayout     = "2006-01-02"
+	minMonthSpread = 3
+)
+
+type Heatmap struct {
+	Chart
+	Rows    []string
+	Columns []string
+	Values  [][]float64
+	Scale   scale.ColorScale
+	Gap     float64
+	Labels  bool
+	Format  func(float64) string
+}
+
+func (c Heatmap) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c Heatmap) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		x      = scale.NewBand(c.Columns, scale.NewRange(0, dim.W))
+		y      = scale.NewBand(c.Rows, scale.NewRange(0, dim.H))
+		colors = c.colors()
+		font   = c.Font.OrDefault()
+		cells  svg.Group
+		labels svg.Group
+	)
+	axes := c.axes(x, y, dim)
+	for _, a := range axes {
+		plot.Append(a.Element())
+	}
+	cells.Class = append(cells.Class, "cells")
+	labels.Class = append(labels.Class, "labels")
+	for i, row := range c.Rows {
+		for j, col := range c.Columns {
+			var (
+				v    = c.value(i, j)
+				rect svg.Rect
+			)
+			rect.Pos = svg.NewPos(x.Scale(col)+c.Gap/2, y.Scale(row)+c.Gap/2)
+			rect.Dim = svg.NewDim(math.Max(0, x.Bandwidth()-c.Gap), math.Max(0, y.Bandwidth()-c.Gap))
+			rect.Class = append(rect.Class, "cell")
+			rect.Data = []svg.Datum{
+				{Name: "row", Value: row},
+				{Name: "column", Value: col},
+			}
+			if math.IsNaN(v) {
+				rect.Fill = svg.NewFill(emptyCell)
+				cells.Append(rect.AsElement())
+				continue
+			}
+			rect.Fill = svg.NewFill(colors.Color(v))
+			rect.Data = append(rect.Data, svg.Datum{Name: "value", Value: v})
+			cells.Append(rect.AsElement())
+
+			if !c.Labels {
+				continue
+			}
+			str := c.format(v)
+			if svg.EstimateWidth(str, font) > rect.W || font.Size > rect.H {
+				continue
+			}
+			text := svg.NewText(str)
+			text.Font = font
+			text.Font.Fill = contrast(colors.Color(v))
+			text.Pos = svg.NewPos(x.Center(col), y.Scale(row)+y.Bandwidth()/2)
+			text.Anchor = svg.AlignMiddle
+			text.Baseline = "middle"
+			labels.Append(text.AsElement())
+		}
+	}
+	plot.Append(cells.AsElement())
+	plot.Append(labels.AsElement())
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.ramp(colors, dim, axes...))
+	return canvas.AsElement()
+}
+
+func (c Heatmap) value(i, j int) float64 {
+	if i >= len(c.Values) || j >= len(c.Values[i]) {
+		return math.NaN()
+	}
+	return c.Values[i][j]
+}
+
+func (c Heatmap) colors() scale.ColorScale {
+	if c.Scale != nil {
+		return c.Scale
+	}
+	var values []float64
+	for i := range c.Values {
+		values = append(values, c.Values[i]...)
+	}
+	return scale.NewSequential(scale.NewRange(extent(values...)), scale.Blues)
+}
+
+func (c Heatmap) format(v float64) string {
+	if c.Format != nil {
+		return c.Format(v)
+	}
+	return formatValue(v)
+}
+
+type CalendarHeatmap struct {
+	Chart
+	Values map[time.Time]float64
+	Starts time.Time
+	Ends   time.Time
+	Scale  scale.ColorScale
+	Cell   float64
+	Gap    float64
+	Monday bool
+}
+
+func (c CalendarHeatmap) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c CalendarHeatmap) Element() svg.Element {
+	var (
+		canvas      = c.canvas()
+		plot        = c.plot()
+		_, dim      = c.area()
+		values      = c.days()
+		first, last = c.bounds(values)
+		origin      = first.AddDate(0, 0, -c.weekday(first))
+		weeks       = daysBetween(origin, last)/7 + 1
+		size        = c.cell(dim, weeks)
+		colors      = c.colors(values)
+		font        = c.Font.OrDefault()
+		cells       svg.Group
+		months      svg.Group
+		prev        = -minMonthSpread
+	)
+	if len(values) == 0 && c.Starts.IsZero() {
+		return canvas.AsElement()
+	}
+	cells.Class = append(cells.Class, "cells")
+	months.Class = append(months.Class, "months")
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		var (
+			index = daysBetween(origin, d)
+			col   = index / 7
+			row   = index % 7
+			rect  svg.Rect
+		)
+		rect.Pos = svg.NewPos(float64(col)*size+c.Gap/2, float64(row)*size+c.Gap/2)
+		rect.Dim = svg.NewDim(math.Max(0, size-c.Gap), math.Max(0, size-c.Gap))
+		rect.Class = append(rect.Class, "day")
+		rect.Data = []svg.Datum{
+			{Name: "date", Value: d.Format(dateLayout)},
+		}
+		if v, ok := values[d]; ok {
+			rect.Fill = svg.NewFill(colors.Color(v))
+			rect.Data = append(rect.Data, svg.Datum{Name: "value", Value: v})
+		} else {
+			rect.Fill = svg.NewFill(emptyCell)
+		}
+		cells.Append(rect.AsElement())
+
+		if (d.Day() == 1 || d.Equal(first)) && col-prev >= minMonthSpread {
+			text := svg.NewText(d.Format("Jan"))
+			text.Font = font
+			text.Pos = svg.NewPos(float64(col)*size, -font.Size/2)
+			months.Append(text.AsElement())
+			prev = col
+		}
+	}
+	plot.Append(months.AsElement())
+	plot.Append(c.weekdays(size, font))
+	plot.Append(cells.AsElement())
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.ramp(colors, dim))
+	return canvas.AsElement()
+}
+
+func (c CalendarHeatmap) weekdays(size float64, font svg.Font) svg.Element {
+	var (
+		grp  svg.Group
+		days = []time.Weekday{time.Monday, time.Wednesday, time.Friday}
+	)
+	grp.Class = append(grp.Class, "weekdays")
+	for _, d := range days {
+		var (
+			row  = c.weekday(time.Date(2006, 1, 1+int(d), 0, 0, 0, 0, time.UTC))
+			text = svg.NewText(d.String()[:3])
+		)
+		text.Font = font
+		text.Pos = svg.NewPos(-defaultTickPadding*2, float64(row)*size+size/2)
+		text.Anchor = svg.AlignEnd
+		text.Baseline = "middle"
+		grp.Append(text.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (c CalendarHeatmap) days() map[time.Time]float64 {
+	values := make(map[time.Time]float64)
+	for t, v := range c.Values {
+		values[truncateDay(t)] += v
+	}
+	return values
+}
+
+func (c CalendarHeatmap) bounds(values map[time.Time]float64) (time.Time, time.Time) {
+	first, last := truncateDay(c.Starts), truncateDay(c.Ends)
+	for t := range values {
+		if c.Starts.IsZero() && (first.IsZero() || t.Before(first)) {
+			first = t
+		}
+		if c.Ends.IsZero() && (last.IsZero() || t.After(last)) {
+			last = t
+		}
+	}
+	if last.Before(first) {
+		last = first
+	}
+	return first, last
+}
+
+func (c CalendarHeatmap) colors(values map[time.Time]float64) scale.ColorScale {
+	if c.Scale != nil {
+		return c.Scale
+	}
+	list := []float64{0}
+	for _, v := range values {
+		list = append(list, v)
+	}
+	return scale.NewSequential(scale.NewRange(extent(list...)), scale.Greens)
+}
+
+func (c CalendarHeatmap) cell(dim svg.Dim, weeks int) float64 {
+	if c.Cell > 0 {
+		return c.Cell
+	}
+	return math.Min(dim.W/float64(weeks), dim.H/7)
+}
+
+func (c CalendarHeatmap) weekday(t time.Time) int {
+	wd := int(t.Weekday())
+	if c.Monday {
+		wd = (wd + 6) % 7
+	}
+	return wd
+}
+
+func truncateDay(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func contrast(color string) string {
+	c, err := scale.ParseColor(color)
+	if err != nil || c.Luminance() > 0.5 {
+		return "black"
+	}
+	return "white"
+}
@@ -0,0 +1,332 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	defaultRampLength    = 200
+	defaultRampThickness = 12
+	defaultRampId        = "legend-ramp"
+)
+
+type Shape int
+
+const (
+	ShapeRect Shape = iota
+	ShapeCircle
+	ShapeLine
+)
+
+type Placement int
+
+const (
+	PlaceRight Placement = iota
+	PlaceBottom
+	PlaceTop
+	PlaceLeft
+	PlaceInsideTopRight
+	PlaceInsideTopLeft
+)
+
+type Entry struct {
+	Label  string
+	Color  string
+	Shape  Shape
+	Stroke svg.Stroke
+}
+
+type Ramp struct {
+	Id        string
+	Domain    scale.Range
+	Colors    []string
+	Length    float64
+	Thickness float64
+	Count     int
+	Format    func(float64) string
+}
+
+type Legend struct {
+	svg.Pos
+	Title      string
+	Entries    []Entry
+	Ramp       *Ramp
+	Horizontal bool
+	Width      float64
+	Height     float64
+	Swatch     float64
+	Spacing    float64
+	Margin     float64
+	Font       svg.Font
+	Measure    func(string, svg.Font) float64
+}
+
+func (l Legend) Element() svg.Element {
+	g := l.Group()
+	return g.AsElement()
+}
+
+func (l Legend) Group() svg.Group {
+	var (
+		grp  svg.Group
+		font = l.Font.OrDefault()
+		top  float64
+	)
+	grp.Class = append(grp.Class, "legend")
+	grp.Transform = svg.Translate(l.X, l.Y)
+	if l.Title != "" {
+		title := svg.NewText(l.Title)
+		title.Font = font
+		title.Font.Weight = "bold"
+		title.Baseline = "hanging"
+		title.Class = append(title.Class, "title")
+		grp.Append(title.AsElement())
+		top = font.Size * labelSpacing * 1.5
+	}
+	if l.Ramp != nil {
+		ramp := l.ramp(font)
+		ramp.Transform = svg.Translate(0, top)
+		grp.Append(ramp.AsElement())
+		return grp
+	}
+	boxes, _ := l.layout(font)
+	for i, e := range l.Entries {
+		var (
+			item svg.Group
+			box  = boxes[i]
+			size = l.swatch()
+		)
+		item.Class = append(item.Class, "entry")
+		item.Transform = svg.Translate(box.X, box.Y+top)
+		item.Append(l.sample(e))
+
+		text := svg.NewText(e.Label)
+		text.Font = font
+		text.Pos = svg.NewPos(size+l.spacing()/2, size/2)
+		text.Baseline = "middle"
+		item.Append(text.AsElement())
+		grp.Append(item.AsElement())
+	}
+	return grp
+}
+
+func (l Legend) Size() svg.Dim {
+	var (
+		font = l.Font.OrDefault()
+		top  float64
+	)
+	if l.Title != "" {
+		top = font.Size * labelSpacing * 1.5
+	}
+	if l.Ramp != nil {
+		dim := l.rampSize(font)
+		dim.H += top
+		return dim
+	}
+	_, dim := l.layout(font)
+	dim.H += top
+	if l.Title != "" {
+		dim.W = math.Max(dim.W, l.measure(l.Title, font))
+	}
+	return dim
+}
+
+func (l *Legend) Place(pos svg.Pos, dim svg.Dim, where Placement) {
+	l.Pos = where.Position(pos, dim, l.Size(), l.Margin)
+}
+
+func (p Placement) Position(pos svg.Pos, dim, size svg.Dim, margin float64) svg.Pos {
+	switch p {
+	case PlaceRight:
+		return svg.NewPos(pos.X+dim.W+margin, pos.Y)
+	case PlaceLeft:
+		return svg.NewPos(pos.X-size.W-margin, pos.Y)
+	case PlaceTop:
+		return svg.NewPos(pos.X+(dim.W-size.W)/2, pos.Y-size.H-margin)
+	case PlaceBottom:
+		return svg.NewPos(pos.X+(dim.W-size.W)/2, pos.Y+dim.H+margin)
+	case PlaceInsideTopRight:
+		return svg.NewPos(pos.X+dim.W-size.W-margin, pos.Y+margin)
+	case PlaceInsideTopLeft:
+		return svg.NewPos(pos.X+margin, pos.Y+margin)
+	default:
+		return pos
+	}
+}
+
+func (l Legend) layout(font svg.Font) ([]svg.Pos, svg.Dim) {
+	var (
+		list   = make([]svg.Pos, len(l.Entries))
+		height = math.Max(l.swatch(), font.Size)
+		gap    = l.spacing()
+		x, y   float64
+		line   float64
+		dim    svg.Dim
+	)
+	for i, e := range l.Entries {
+		width := l.swatch() + gap/2 + l.measure(e.Label, font)
+		if l.Horizontal {
+			if l.Width > 0 && x > 0 && x+width > l.Width {
+				x = 0
+				y += height + gap
+			}
+			list[i] = svg.NewPos(x, y)
+			x += width + gap
+			dim.W = math.Max(dim.W, x-gap)
+			dim.H = y + height
+		} else {
+			if l.Height > 0 && y > 0 && y+height > l.Height {
+				y = 0
+				x += line + gap
+				line = 0
+			}
+			list[i] = svg.NewPos(x, y)
+			y += height + gap/2
+			line = math.Max(line, width)
+			dim.W = math.Max(dim.W, x+line)
+			dim.H = math.Max(dim.H, y-gap/2)
+		}
+	}
+	return list, dim
+}
+
+func (l Legend) sample(e Entry) svg.Element {
+	size := l.swatch()
+	switch e.Shape {
+	case ShapeCircle:
+		var c svg.Circle
+		c.Pos = svg.NewPos(size/2, size/2)
+		c.Radius = size / 2
+		c.Fill = svg.NewFill(e.Color)
+		c.Class = append(c.Class, "swatch")
+		return c.AsElement()
+	case ShapeLine:
+		var (
+			line   = svg.NewLine(svg.NewPos(0, size/2), svg.NewPos(size, size/2))
+			stroke = e.Stroke
+		)
+		if stroke.IsZero() {
+			stroke = svg.NewStroke(e.Color, 2)
+		}
+		line.Stroke = stroke
+		line.Class = append(line.Class, "swatch")
+		return line.AsElement()
+	default:
+		var r svg.Rect
+		r.Dim = svg.NewDim(size, size)
+		r.Fill = svg.NewFill(e.Color)
+		r.Stroke = e.Stroke
+		r.Class = append(r.Class, "swatch")
+		return r.AsElement()
+	}
+}
+
+func (l Legend) ramp(font svg.Font) svg.Group {
+	var (
+		grp       svg.Group
+		defs      svg.Defs
+		grad      svg.Linear
+		rect      svg.Rect
+		r         = l.Ramp
+		length    = r.length()
+		thickness = r.thickness()
+		id        = r.Id
+		rg        = scale.NewRange(0, length)
+	)
+	if id == "" {
+		id = defaultRampId
+	}
+	grad.Id = id
+	if l.Horizontal {
+		grad.Pos2 = svg.NewPos(1, 0)
+		rect.Dim = svg.NewDim(length, thickness)
+	} else {
+		grad.Pos1 = svg.NewPos(0, 1)
+		rect.Dim = svg.NewDim(thickness, length)
+		rg = scale.NewRange(length, 0)
+	}
+	for i, c := range r.Colors {
+		offset := 0.0
+		if len(r.Colors) > 1 {
+			offset = float64(i) / float64(len(r.Colors)-1)
+		}
+		stop := svg.NewStop(offset, c)
+		grad.Append(stop.AsElement())
+	}
+	defs.Append(grad.AsElement())
+	rect.Fill = svg.NewFill(svg.UrlFor(id))
+	rect.Class = append(rect.Class, "ramp")
+
+	axis := NewAxis(scale.NewLinear(r.Domain, rg), Right)
+	axis.Pos = svg.NewPos(thickness, 0)
+	if l.Horizontal {
+		axis.Orient = Bottom
+		axis.Pos = svg.NewPos(0, thickness)
+	}
+	axis.Count = r.Count
+	axis.Format = r.Format
+	axis.Font = font
+	axis.OuterSize = 0
+	axis.OmitDomain = true
+	axis.TickSize = 4
+
+	grp.Append(defs.AsElement())
+	grp.Append(rect.AsElement())
+	grp.Append(axis.Element())
+	return grp
+}
+
+func (l Legend) rampSize(font svg.Font) svg.Dim {
+	var (
+		r     = l.Ramp
+		axis  = NewAxis(scale.NewLinear(r.Domain, scale.NewRange(0, r.length())), Right)
+		width float64
+	)
+	axis.Count = r.Count
+	axis.Format = r.Format
+	for _, t := range axis.Ticks() {
+		width = math.Max(width, l.measure(t.Label, font))
+	}
+	if l.Horizontal {
+		return svg.NewDim(r.length()+width, r.thickness()+4+defaultTickPadding+font.Size)
+	}
+	return svg.NewDim(r.thickness()+4+defaultTickPadding+width, r.length()+font.Size)
+}
+
+func (l Legend) swatch() float64 {
+	if l.Swatch <= 0 {
+		return swatchSize
+	}
+	return l.Swatch
+}
+
+func (l Legend) spacing() float64 {
+	if l.Spacing <= 0 {
+		return swatchSize
+	}
+	return l.Spacing
+}
+
+func (l Legend) measure(str string, font svg.Font) float64 {
+	if l.Measure != nil {
+		return l.Measure(str, font)
+	}
+	return svg.EstimateWidth(str, font)
+}
+
+func (r Ramp) length() float64 {
+	if r.Length <= 0 {
+		return defaultRampLength
+	}
+	return r.Length
+}
+
+func (r Ramp) thickness() float64 {
+	if r.Thickness <= 0 {
+		return defaultRampThickness
+	}
+	return r.Thickness
+}
@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"io"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const defaultMarkerRadius = 3
+
+type LineChart struct {
+	Chart
+	Series      []Series
+	Markers     bool
+	MarkerSize  float64
+	StrokeWidth float64
+}
+
+func (c LineChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c LineChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		x, y   = c.scales(dim)
+	)
+	axes := c.axes(x, y, dim)
+	for _, a := range axes {
+		plot.Append(a.Element())
+	}
+	for i, s := range c.Series {
+		var (
+			grp  svg.Group
+			line = c.line(s, i, x, y)
+		)
+		grp.Class = append(grp.Class, "series")
+		grp.Data = append(grp.Data, seriesData(s))
+		grp.Append(line.AsElement())
+		if c.Markers {
+			for _, p := range s.Points {
+				m := c.marker(s, i, p, x, y)
+				grp.Append(m.AsElement())
+			}
+		}
+		plot.Append(grp.AsElement())
+	}
+	canvas.Append(plot.AsElement())
+	entries := c.entries(c.Series, ShapeLine)
+	for i, s := range c.Series {
+		entries[i].Stroke = c.stroke(i, s)
+	}
+	canvas.Append(c.legend(entries, dim, axes...))
+	return canvas.AsElement()
+}
+
+func (c LineChart) line(s Series, i int, x, y scale.Linear) svg.Path {
+	var path svg.Path
+	for j, p := range s.Points {
+		pos := scale.Pos(x, y, p.X, p.Y)
+		if j == 0 {
+			path.AbsMoveTo(pos)
+		} else {
+			path.AbsLineTo(pos)
+		}
+	}
+	path.Class = append(path.Class, "line")
+	path.Fill = svg.NewFill("none")
+	path.Stroke = c.stroke(i, s)
+	return path
+}
+
+func (c LineChart) stroke(i int, s Series) svg.Stroke {
+	width := c.StrokeWidth
+	if width <= 0 {
+		width = 2
+	}
+	stroke := svg.NewStroke(c.color(i, s), width)
+	stroke.LineJoin = "round"
+	return stroke
+}
+
+func (c LineChart) marker(s Series, i int, p Point, x, y scale.Linear) svg.Circle {
+	var (
+		circle svg.Circle
+		radius = c.MarkerSize
+	)
+	if radius <= 0 {
+		radius = defaultMarkerRadius
+	}
+	circle.Class = append(circle.Class, "marker")
+	circle.Pos = scale.Pos(x, y, p.X, p.Y)
+	circle.Radius = radius
+	circle.Fill = svg.NewFill(c.color(i, s))
+	circle.Data = pointData(s, p)
+	return circle
+}
+
+func (c LineChart) scales(dim svg.Dim) (scale.Linear, scale.Linear) {
+	var xs, ys []float64
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			xs = append(xs, p.X)
+			ys = append(ys, p.Y)
+		}
+	}
+	var (
+		x = scale.NewLinear(scale.NewRange(extent(xs...)), scale.NewRange(0, dim.W))
+		y = scale.NewLinear(scale.NewRange(extent(ys...)), scale.NewRange(dim.H, 0))
+	)
+	return x.Nice(defaultTicks), y.Nice(defaultTicks)
+}
+
+func pointData(s Series, p Point) []svg.Datum {
+	data := []svg.Datum{
+		seriesData(s),
+		{Name: "x", Value: p.X},
+		{Name: "y", Value: p.Y},
+	}
+	if p.Label != "" {
+		data = append(data, svg.Datum{Name: "label", Value: p.Label})
+	}
+	return data
+}
@@ -0,0 +1,351 @@
+package chart
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/hierarchy"
+)
+
+const (
+	defaultDonutRatio = 0.5
+	leaderLength      = 12
+	leaderOffset      = 24
+	labelSpacing      = 1.2
+	ringFade          = 0.15
+	ringOpacity       = 0.2
+)
+
+type LabelPosition int
+
+const (
+	LabelInside LabelPosition = iota
+	LabelOutside
+	LabelNone
+)
+
+type PieChart struct {
+	Chart
+	Categories []string
+	Values     []float64
+	Inner      float64
+	Corner     float64
+	Pad        float64
+	Starts     float64
+	Ends       float64
+	Labels     LabelPosition
+	Sort       bool
+}
+
+func (c PieChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c PieChart) Element() svg.Element {
+	var (
+		canvas      = c.canvas()
+		plot        = c.plot()
+		_, dim      = c.area()
+		center      = svg.NewPos(dim.W/2, dim.H/2)
+		radius      = math.Min(dim.W, dim.H) / 2
+		series      []Series
+		arcs        = c.arcs()
+		outside     []outsideLabel
+		total       float64
+		font        = c.Font.OrDefault()
+		slices, lbl svg.Group
+	)
+	if c.Labels == LabelOutside {
+		radius -= leaderLength + leaderOffset
+	}
+	for _, v := range c.Values {
+		total += math.Abs(v)
+	}
+	slices.Class = append(slices.Class, "slices")
+	lbl.Class = append(lbl.Class, "labels")
+	for _, a := range arcs {
+		var (
+			s   = Series{Name: c.Categories[a.index]}
+			val = c.Values[a.index]
+		)
+		a.Pos = center
+		a.Outer = radius
+		a.Inner = radius * c.Inner
+		a.Corner = c.Corner
+		a.Pad = c.Pad
+
+		path := a.Path()
+		path.Class = append(path.Class, "slice")
+		path.Fill = svg.NewFill(c.color(a.index, s))
+		path.Stroke = svg.NewStroke("white", 1)
+		path.Data = []svg.Datum{
+			{Name: "category", Value: s.Name},
+			{Name: "value", Value: val},
+			{Name: "percent", Value: math.Round(math.Abs(val)/total*10000) / 100},
+		}
+		slices.Append(path.AsElement())
+
+		switch c.Labels {
+		case LabelInside:
+			var (
+				pos  = a.Centroid()
+				span = (a.Ends - a.Starts) * (a.Inner + a.Outer) / 2
+			)
+			if svg.EstimateWidth(s.Name, font) > span {
+				continue
+			}
+			text := svg.NewText(s.Name)
+			text.Pos = pos
+			text.Font = font
+			text.Anchor = svg.AlignMiddle
+			text.Baseline = "middle"
+			lbl.Append(text.AsElement())
+		case LabelOutside:
+			outside = append(outside, outsideLabel{arc: a.Arc, label: s.Name})
+		}
+	}
+	plot.Append(slices.AsElement())
+	if len(outside) > 0 {
+		for _, e := range placeOutside(outside, center, radius, font) {
+			lbl.Append(e)
+		}
+	}
+	plot.Append(lbl.AsElement())
+	for i := range c.Categories {
+		series = append(series, Series{Name: c.Categories[i]})
+	}
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(c.entries(series, ShapeRect), dim))
+	return canvas.AsElement()
+}
+
+type pieArc struct {
+	Arc
+	index int
+}
+
+func (c PieChart) arcs() []pieArc {
+	var (
+		starts = c.Starts
+		ends   = c.Ends
+		total  float64
+		order  = make([]int, 0, len(c.Categories))
+		list   []pieArc
+	)
+	if starts == ends {
+		ends = starts + 2*math.Pi
+	}
+	for i := range c.Categories {
+		if i >= len(c.Values) {
+			break
+		}
+		total += math.Abs(c.Values[i])
+		order = append(order, i)
+	}
+	if total == 0 {
+		return nil
+	}
+	if c.Sort {
+		sort.SliceStable(order, func(i, j int) bool {
+			return math.Abs(c.Values[order[i]]) > math.Abs(c.Values[order[j]])
+		})
+	}
+	pos := starts
+	for _, i := range order {
+		var a pieArc
+		a.index = i
+		a.Starts = pos
+		a.Ends = pos + (ends-starts)*math.Abs(c.Values[i])/total
+		pos = a.Ends
+		list = append(list, a)
+	}
+	return list
+}
+
+type DonutChart struct {
+	PieChart
+}
+
+func (c DonutChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c DonutChart) Element() svg.Element {
+	if c.Inner <= 0 {
+		c.Inner = defaultDonutRatio
+	}
+	return c.PieChart.Element()
+}
+
+type SunburstChart struct {
+	Chart
+	Root   *hierarchy.Node
+	Corner float64
+	Pad    float64
+	Labels LabelPosition
+}
+
+func (c SunburstChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c SunburstChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		center = svg.NewPos(dim.W/2, dim.H/2)
+		radius = math.Min(dim.W, dim.H) / 2
+		font   = c.Font.OrDefault()
+		series []Series
+		arcs   svg.Group
+		labels svg.Group
+	)
+	if c.Root == nil {
+		return canvas.AsElement()
+	}
+	for _, n := range c.Root.Children {
+		series = append(series, Series{Name: n.Name, Color: n.Color})
+	}
+	arcs.Class = append(arcs.Class, "arcs")
+	labels.Class = append(labels.Class, "labels")
+	cells := hierarchy.Partition(c.Root, 2*math.Pi, radius)
+	for i, cell := range cells {
+		if cell.Depth == 0 || cell.Width() <= 0 {
+			continue
+		}
+		var (
+			branch = hierarchy.Ancestor(cells, i, 1)
+			index  = branchIndex(c.Root, cells[branch].Node)
+			arc    = Arc{
+				Pos:    center,
+				Inner:  cell.Y0,
+				Outer:  cell.Y1,
+				Starts: cell.X0,
+				Ends:   cell.X1,
+				Corner: c.Corner,
+				Pad:    c.Pad,
+			}
+			color = cell.Node.Color
+		)
+		if color == "" && index >= 0 {
+			color = c.color(index, series[index])
+		}
+		path := arc.Path()
+		path.Class = append(path.Class, "arc")
+		path.Fill = svg.NewFill(color)
+		path.Fill.Opacity = math.Max(ringOpacity, 1-ringFade*float64(cell.Depth-1))
+		path.Stroke = svg.NewStroke("white", 1)
+		path.Data = []svg.Datum{
+			{Name: "name", Value: cell.Node.Name},
+			{Name: "value", Value: cell.Node.Sum()},
+			{Name: "depth", Value: cell.Depth},
+			{Name: "path", Value: hierarchy.Path(cells, i)},
+		}
+		arcs.Append(path.AsElement())
+
+		if c.Labels != LabelInside {
+			continue
+		}
+		var (
+			width = svg.EstimateWidth(cell.Node.Name, font)
+			mid   = (cell.Y0 + cell.Y1) / 2
+		)
+		if width > cell.Height() || cell.Width()*mid < font.Size {
+			continue
+		}
+		var (
+			pos   = arc.Centroid()
+			angle = (cell.X0+cell.X1)/2*180/math.Pi - 90
+			text  = svg.NewText(cell.Node.Name)
+		)
+		if angle > 90 {
+			angle -= 180
+		}
+		text.Pos = pos
+		text.Font = font
+		text.Anchor = svg.AlignMiddle
+		text.Baseline = "middle"
+		text.Transform.Rotate(angle, pos.X, pos.Y)
+		labels.Append(text.AsElement())
+	}
+	plot.Append(arcs.AsElement())
+	plot.Append(labels.AsElement())
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(c.entries(series, ShapeRect), dim))
+	return canvas.AsElement()
+}
+
+type outsideLabel struct {
+	arc   Arc
+	label string
+	pos   svg.Pos
+	right bool
+}
+
+func placeOutside(list []outsideLabel, center svg.Pos, radius float64, font svg.Font) []svg.Element {
+	var (
+		left  []*outsideLabel
+		right []*outsideLabel
+		els   []svg.Element
+	)
+	for i := range list {
+		var (
+			l   = &list[i]
+			mid = math.Mod((l.arc.Starts+l.arc.Ends)/2, 2*math.Pi)
+			pos = l.arc.Point(radius + leaderLength)
+		)
+		if mid < 0 {
+			mid += 2 * math.Pi
+		}
+		l.right = mid < math.Pi
+		l.pos = pos
+		if l.right {
+			l.pos.X = center.X + radius + leaderOffset
+			right = append(right, l)
+		} else {
+			l.pos.X = center.X - radius - leaderOffset
+			left = append(left, l)
+		}
+	}
+	for _, side := range [][]*outsideLabel{left, right} {
+		sort.Slice(side, func(i, j int) bool {
+			return side[i].pos.Y < side[j].pos.Y
+		})
+		for i := 1; i < len(side); i++ {
+			if min := side[i-1].pos.Y + font.Size*labelSpacing; side[i].pos.Y < min {
+				side[i].pos.Y = min
+			}
+		}
+	}
+	for _, l := range list {
+		var (
+			line svg.PolyLine
+			text = svg.NewText(l.label)
+			elb  = l.arc.Point(radius + leaderLength)
+		)
+		elb.Y = l.pos.Y
+		line.Points = []svg.Pos{
+			l.arc.Point(radius),
+			elb,
+			l.pos,
+		}
+		line.Class = append(line.Class, "leader")
+		line.Fill = svg.NewFill("none")
+		line.Stroke = svg.NewStroke("gray", 1)
+
+		text.Font = font
+		text.Baseline = "middle"
+		if l.right {
+			text.Pos = l.pos.Adjust(4, 0)
+			text.Anchor = svg.AlignStart
+		} else {
+			text.Pos = l.pos.Adjust(-4, 0)
+			text.Anchor = svg.AlignEnd
+		}
+		els = append(els, line.AsElement(), text.AsElement())
+	}
+	return els
+}
@@ -0,0 +1,94 @@
+package chart
+
+import (
+	"io"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	defaultMinRadius = 2
+	defaultMaxRadius = 16
+)
+
+type ScatterChart struct {
+	Chart
+	Series    []Series
+	MinRadius float64
+	MaxRadius float64
+	Opacity   float64
+}
+
+func (c ScatterChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c ScatterChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		line   = LineChart{Chart: c.Chart, Series: c.Series}
+		x, y   = line.scales(dim)
+		size   = c.sizes()
+	)
+	axes := c.axes(x, y, dim)
+	for _, a := range axes {
+		plot.Append(a.Element())
+	}
+	for i, s := range c.Series {
+		var grp svg.Group
+		grp.Class = append(grp.Class, "series")
+		grp.Data = append(grp.Data, seriesData(s))
+		for _, p := range s.Points {
+			var circle svg.Circle
+			circle.Class = append(circle.Class, "dot")
+			circle.Pos = scale.Pos(x, y, p.X, p.Y)
+			circle.Radius = size(p.Size)
+			circle.Fill = svg.NewFill(c.color(i, s))
+			if c.Opacity > 0 {
+				circle.Fill.Opacity = c.Opacity
+			}
+			circle.Data = pointData(s, p)
+			if p.Size != 0 {
+				circle.Data = append(circle.Data, svg.Datum{Name: "size", Value: p.Size})
+			}
+			grp.Append(circle.AsElement())
+		}
+		plot.Append(grp.AsElement())
+	}
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(c.entries(c.Series, ShapeCircle), dim, axes...))
+	return canvas.AsElement()
+}
+
+func (c ScatterChart) sizes() func(float64) float64 {
+	var (
+		min    = c.MinRadius
+		max    = c.MaxRadius
+		values []float64
+	)
+	if min <= 0 {
+		min = defaultMinRadius
+	}
+	if max <= 0 {
+		max = defaultMaxRadius
+	}
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			if p.Size > 0 {
+				values = append(values, p.Size)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return func(float64) float64 {
+			return defaultMarkerRadius + 1
+		}
+	}
+	_, hi := extent(values...)
+	sqrt := scale.NewSqrt(scale.NewRange(0, hi), scale.NewRange(min, max))
+	sqrt.Clamp = true
+	return sqrt.Scale
+}
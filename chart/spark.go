@@ -0,0 +1,444 @@
+package chart
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	defaultSparkWidth     = 100
+	defaultSparkHeight    = 20
+	defaultSparkPrecision = 1
+	defaultSparkRadius    = 1.5
+	defaultSparkStroke    = 1
+	defaultSparkGap       = 1
+	minColor              = "#d62728"
+	maxColor              = "#2ca02c"
+	lastColor             = "#ff7f0e"
+)
+
+var bulletRanges = []string{
+	"#a8a8a8",
+	"#c8c8c8",
+	"#e0e0e0",
+}
+
+type Mark int
+
+const (
+	MarkMin Mark = 1 << iota
+	MarkMax
+	MarkLast
+)
+
+func (m Mark) has(k Mark) bool {
+	return m&k == k
+}
+
+type Spark struct {
+	svg.Dim
+	Color     string
+	Marks     Mark
+	MinColor  string
+	MaxColor  string
+	LastColor string
+	Precision *int
+}
+
+func (s Spark) canvas(kind string) svg.SVG {
+	c := svg.NewSVG()
+	c.OmitProlog = true
+	c.Dim = s.dim()
+	c.Class = append(c.Class, "sparkline", kind)
+	return c
+}
+
+func (s Spark) dim() svg.Dim {
+	d := s.Dim
+	if d.W <= 0 {
+		d.W = defaultSparkWidth
+	}
+	if d.H <= 0 {
+		d.H = defaultSparkHeight
+	}
+	return d
+}
+
+func (s Spark) round(v float64) float64 {
+	prec := defaultSparkPrecision
+	if s.Precision != nil && *s.Precision >= 0 {
+		prec = *s.Precision
+	}
+	pow := math.Pow10(prec)
+	return math.Round(v*pow) / pow
+}
+
+func (s Spark) pos(x, y float64) svg.Pos {
+	return svg.NewPos(s.round(x), s.round(y))
+}
+
+func (s Spark) color() string {
+	if s.Color == "" {
+		return Palette[0]
+	}
+	return s.Color
+}
+
+func (s Spark) markColor(k Mark) string {
+	var color string
+	switch k {
+	case MarkMin:
+		color = s.MinColor
+		if color == "" {
+			color = minColor
+		}
+	case MarkMax:
+		color = s.MaxColor
+		if color == "" {
+			color = maxColor
+		}
+	case MarkLast:
+		color = s.LastColor
+		if color == "" {
+			color = lastColor
+		}
+	}
+	return color
+}
+
+func (s Spark) marked(values []float64) map[int]Mark {
+	var (
+		marks = make(map[int]Mark)
+		min   = -1
+		max   = -1
+		last  = -1
+	)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if min < 0 || v < values[min] {
+			min = i
+		}
+		if max < 0 || v > values[max] {
+			max = i
+		}
+		last = i
+	}
+	if last < 0 {
+		return marks
+	}
+	if s.Marks.has(MarkLast) {
+		marks[last] = MarkLast
+	}
+	if s.Marks.has(MarkMax) {
+		marks[max] = MarkMax
+	}
+	if s.Marks.has(MarkMin) {
+		marks[min] = MarkMin
+	}
+	return marks
+}
+
+type Sparkline struct {
+	Spark
+	Values      []float64
+	Area        string
+	StrokeWidth float64
+	Radius      float64
+}
+
+func (c Sparkline) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c Sparkline) Element() svg.Element {
+	var (
+		canvas   = c.canvas("line")
+		dim      = c.dim()
+		inset    = c.strokeWidth() / 2
+		min, max = extent(c.Values...)
+		marks    = c.marked(c.Values)
+	)
+	if len(marks) > 0 {
+		inset = math.Max(inset, c.radius())
+	}
+	var (
+		x    = scale.NewLinear(scale.NewRange(0, float64(len(c.Values)-1)), scale.NewRange(inset, dim.W-inset))
+		y    = scale.NewLinear(scale.NewRange(min, max), scale.NewRange(dim.H-inset, inset))
+		line svg.Path
+		area svg.Path
+		prev = -1
+	)
+	for i, v := range c.Values {
+		if math.IsNaN(v) {
+			if prev >= 0 && c.Area != "" {
+				area.AbsLineTo(c.pos(x.Scale(float64(prev)), dim.H))
+				area.ClosePath()
+			}
+			prev = -1
+			continue
+		}
+		pos := c.pos(x.Scale(float64(i)), y.Scale(v))
+		if prev < 0 {
+			line.AbsMoveTo(pos)
+			area.AbsMoveTo(c.pos(pos.X, dim.H))
+		} else {
+			line.AbsLineTo(pos)
+		}
+		area.AbsLineTo(pos)
+		prev = i
+	}
+	if prev >= 0 && c.Area != "" {
+		area.AbsLineTo(c.pos(x.Scale(float64(prev)), dim.H))
+		area.ClosePath()
+	}
+	if c.Area != "" {
+		area.Class = append(area.Class, "area")
+		area.Fill = svg.NewFill(c.Area)
+		canvas.Append(area.AsElement())
+	}
+	line.Class = append(line.Class, "line")
+	line.Fill = svg.NewFill("none")
+	line.Stroke = svg.NewStroke(c.color(), c.strokeWidth())
+	canvas.Append(line.AsElement())
+
+	for _, i := range sortedMarks(marks) {
+		var (
+			k      = marks[i]
+			circle svg.Circle
+		)
+		circle.Pos = c.pos(x.Scale(float64(i)), y.Scale(c.Values[i]))
+		circle.Radius = c.radius()
+		circle.Fill = svg.NewFill(c.markColor(k))
+		circle.Class = append(circle.Class, markClass(k))
+		circle.Data = []svg.Datum{{Name: "value", Value: c.Values[i]}}
+		canvas.Append(circle.AsElement())
+	}
+	return canvas.AsElement()
+}
+
+func (c Sparkline) strokeWidth() float64 {
+	if c.StrokeWidth <= 0 {
+		return defaultSparkStroke
+	}
+	return c.StrokeWidth
+}
+
+func (c Sparkline) radius() float64 {
+	if c.Radius <= 0 {
+		return defaultSparkRadius
+	}
+	return c.Radius
+}
+
+type SparkBar struct {
+	Spark
+	Values   []float64
+	Negative string
+	Gap      float64
+}
+
+func (c SparkBar) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c SparkBar) Element() svg.Element {
+	var (
+		canvas   = c.canvas("bar")
+		dim      = c.dim()
+		min, max = extent(c.Values...)
+		marks    = c.marked(c.Values)
+	)
+	if len(c.Values) == 0 {
+		return canvas.AsElement()
+	}
+	var (
+		band  = dim.W / float64(len(c.Values))
+		width = math.Max(band-c.gap(), 1)
+		y     = scale.NewLinear(scale.NewRange(math.Min(min, 0), math.Max(max, 0)), scale.NewRange(dim.H, 0))
+		zero  = y.Scale(0)
+	)
+	for i, v := range c.Values {
+		if math.IsNaN(v) {
+			continue
+		}
+		var (
+			rect  svg.Rect
+			top   = math.Min(y.Scale(v), zero)
+			color = c.color()
+		)
+		if v < 0 && c.Negative != "" {
+			color = c.Negative
+		}
+		if k, ok := marks[i]; ok {
+			color = c.markColor(k)
+			rect.Class = append(rect.Class, markClass(k))
+		}
+		rect.Pos = c.pos(float64(i)*band+(band-width)/2, top)
+		rect.Dim = svg.NewDim(c.round(width), c.round(math.Abs(y.Scale(v)-zero)))
+		rect.Fill = svg.NewFill(color)
+		rect.Data = []svg.Datum{{Name: "value", Value: v}}
+		canvas.Append(rect.AsElement())
+	}
+	return canvas.AsElement()
+}
+
+func (c SparkBar) gap() float64 {
+	if c.Gap <= 0 {
+		return defaultSparkGap
+	}
+	return c.Gap
+}
+
+type WinLoss struct {
+	Spark
+	Values []float64
+	Win    string
+	Loss   string
+	Gap    float64
+}
+
+func (c WinLoss) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c WinLoss) Element() svg.Element {
+	var (
+		canvas = c.canvas("winloss")
+		dim    = c.dim()
+		band   = dim.W / float64(len(c.Values))
+		width  = math.Max(band-c.gap(), 1)
+		half   = dim.H / 2
+		sep    = float64(defaultSparkGap) / 2
+	)
+	for i, v := range c.Values {
+		if v == 0 || math.IsNaN(v) {
+			continue
+		}
+		var (
+			rect  svg.Rect
+			color = c.Win
+			top   = 0.0
+		)
+		if color == "" {
+			color = c.color()
+		}
+		rect.Class = append(rect.Class, "win")
+		if v < 0 {
+			color = c.Loss
+			if color == "" {
+				color = minColor
+			}
+			top = half + sep
+			rect.Class[0] = "loss"
+		}
+		rect.Pos = c.pos(float64(i)*band+(band-width)/2, top)
+		rect.Dim = svg.NewDim(c.round(width), c.round(half-sep))
+		rect.Fill = svg.NewFill(color)
+		rect.Data = []svg.Datum{{Name: "value", Value: v}}
+		canvas.Append(rect.AsElement())
+	}
+	return canvas.AsElement()
+}
+
+func (c WinLoss) gap() float64 {
+	if c.Gap <= 0 {
+		return defaultSparkGap
+	}
+	return c.Gap
+}
+
+type Bullet struct {
+	Spark
+	Value       float64
+	Target      float64
+	Ranges      []float64
+	Max         float64
+	RangeColors []string
+}
+
+func (c Bullet) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c Bullet) Element() svg.Element {
+	var (
+		canvas = c.canvas("bullet")
+		dim    = c.dim()
+		ranges = append([]float64{}, c.Ranges...)
+		x      = scale.NewLinear(scale.NewRange(0, c.max()), scale.NewRange(0, dim.W))
+		colors = c.RangeColors
+	)
+	if len(colors) == 0 {
+		colors = bulletRanges
+	}
+	sort.Float64s(ranges)
+	for i := len(ranges) - 1; i >= 0; i-- {
+		var rect svg.Rect
+		rect.Pos = svg.NewPos(0, 0)
+		rect.Dim = svg.NewDim(c.round(x.Scale(ranges[i])), c.round(dim.H))
+		rect.Fill = svg.NewFill(colors[i%len(colors)])
+		rect.Class = append(rect.Class, "range")
+		rect.Data = []svg.Datum{{Name: "value", Value: ranges[i]}}
+		canvas.Append(rect.AsElement())
+	}
+
+	var measure svg.Rect
+	measure.Pos = c.pos(0, dim.H/3)
+	measure.Dim = svg.NewDim(c.round(x.Scale(math.Max(c.Value, 0))), c.round(dim.H/3))
+	measure.Fill = svg.NewFill(c.color())
+	measure.Class = append(measure.Class, "measure")
+	measure.Data = []svg.Datum{{Name: "value", Value: c.Value}}
+	canvas.Append(measure.AsElement())
+
+	if c.Target > 0 {
+		var (
+			pos    = x.Scale(c.Target)
+			target = svg.NewLine(c.pos(pos, dim.H/6), c.pos(pos, dim.H*5/6))
+		)
+		target.Stroke = svg.NewStroke("#000000", 2)
+		target.Class = append(target.Class, "target")
+		target.Data = []svg.Datum{{Name: "value", Value: c.Target}}
+		canvas.Append(target.AsElement())
+	}
+	return canvas.AsElement()
+}
+
+func (c Bullet) max() float64 {
+	if c.Max > 0 {
+		return c.Max
+	}
+	max := math.Max(c.Value, c.Target)
+	for _, r := range c.Ranges {
+		max = math.Max(max, r)
+	}
+	if max <= 0 {
+		return 1
+	}
+	return max
+}
+
+func sortedMarks(marks map[int]Mark) []int {
+	list := make([]int, 0, len(marks))
+	for i := range marks {
+		list = append(list, i)
+	}
+	sort.Ints(list)
+	return list
+}
+
+func markClass(k Mark) string {
+	switch k {
+	case MarkMin:
+		return "min"
+	case MarkMax:
+		return "max"
+	default:
+		return "last"
+	}
+}
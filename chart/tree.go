@@ -0,0 +1,233 @@
+package chart
+
+import (
+	"io"
+	"math"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/hierarchy"
+)
+
+const defaultNodeRadius = 4
+
+type LinkStyle int
+
+const (
+	LinkCurve LinkStyle = iota
+	LinkLine
+	LinkElbow
+)
+
+type TreeOrient int
+
+const (
+	TreeVertical TreeOrient = iota
+	TreeHorizontal
+	TreeRadial
+)
+
+type TreeChart struct {
+	Chart
+	Root       *hierarchy.Node
+	Cluster    bool
+	Link       LinkStyle
+	Orient     TreeOrient
+	NodeRadius float64
+	LinkStroke svg.Stroke
+}
+
+func (c TreeChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c TreeChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		font   = c.Font.OrDefault()
+		links  svg.Group
+		nodes  svg.Group
+	)
+	if c.Root == nil {
+		return canvas.AsElement()
+	}
+	var (
+		points  = c.layout(dim)
+		project = c.projection(dim)
+	)
+	links.Class = append(links.Class, "links")
+	nodes.Class = append(nodes.Class, "nodes")
+	for i, p := range points {
+		if p.Parent >= 0 {
+			e := c.link(points[p.Parent], p, project)
+			links.Append(e)
+		}
+		var (
+			grp    svg.Group
+			circle svg.Circle
+			pos    = project(p.X, p.Y)
+		)
+		grp.Class = append(grp.Class, "node")
+		if p.Node.Leaf() {
+			grp.Class = append(grp.Class, "leaf")
+		}
+		grp.Data = []svg.Datum{
+			{Name: "name", Value: p.Node.Name},
+			{Name: "depth", Value: p.Depth},
+		}
+		circle.Pos = pos
+		circle.Radius = c.radius()
+		circle.Fill = svg.NewFill(c.nodeColor(points, i))
+		grp.Append(circle.AsElement())
+		grp.Append(c.label(p, pos, font))
+		nodes.Append(grp.AsElement())
+	}
+	plot.Append(links.AsElement())
+	plot.Append(nodes.AsElement())
+	canvas.Append(plot.AsElement())
+	return canvas.AsElement()
+}
+
+func (c TreeChart) layout(dim svg.Dim) []hierarchy.Point {
+	var (
+		breadth = dim.W
+		depth   = dim.H
+	)
+	switch c.Orient {
+	case TreeHorizontal:
+		breadth, depth = dim.H, dim.W
+	case TreeRadial:
+		breadth, depth = 2*math.Pi, math.Min(dim.W, dim.H)/2
+	}
+	if c.Cluster {
+		return hierarchy.Cluster(c.Root, breadth, depth)
+	}
+	return hierarchy.Tree(c.Root, breadth, depth)
+}
+
+func (c TreeChart) projection(dim svg.Dim) func(float64, float64) svg.Pos {
+	switch c.Orient {
+	case TreeHorizontal:
+		return func(x, y float64) svg.Pos {
+			return svg.NewPos(y, x)
+		}
+	case TreeRadial:
+		center := svg.NewPos(dim.W/2, dim.H/2)
+		return func(x, y float64) svg.Pos {
+			return center.Adjust(y*math.Sin(x), -y*math.Cos(x))
+		}
+	default:
+		return func(x, y float64) svg.Pos {
+			return svg.NewPos(x, y)
+		}
+	}
+}
+
+func (c TreeChart) link(parent, child hierarchy.Point, project func(float64, float64) svg.Pos) svg.Element {
+	var (
+		src    = project(parent.X, parent.Y)
+		dst    = project(child.X, child.Y)
+		mid    = (parent.Y + child.Y) / 2
+		stroke = c.LinkStroke
+		data   = []svg.Datum{
+			{Name: "source", Value: parent.Node.Name},
+			{Name: "target", Value: child.Node.Name},
+		}
+	)
+	if stroke.IsZero() {
+		stroke = svg.NewStroke("#999999", 1)
+	}
+	switch c.Link {
+	case LinkLine:
+		line := svg.NewLine(src, dst)
+		line.Class = append(line.Class, "link")
+		line.Stroke = stroke
+		line.Data = data
+		return line.AsElement()
+	case LinkElbow:
+		var poly svg.PolyLine
+		poly.Points = []svg.Pos{
+			src,
+			project(parent.X, mid),
+			project(child.X, mid),
+			dst,
+		}
+		poly.Class = append(poly.Class, "link")
+		poly.Fill = svg.NewFill("none")
+		poly.Stroke = stroke
+		poly.Data = data
+		return poly.AsElement()
+	default:
+		var path svg.Path
+		path.AbsMoveTo(src)
+		path.AbsCubicCurve(dst, project(parent.X, mid), project(child.X, mid))
+		path.Class = append(path.Class, "link")
+		path.Fill = svg.NewFill("none")
+		path.Stroke = stroke
+		path.Data = data
+		return path.AsElement()
+	}
+}
+
+func (c TreeChart) label(p hierarchy.Point, pos svg.Pos, font svg.Font) svg.Element {
+	var (
+		text   = svg.NewText(p.Node.Name)
+		offset = c.radius() + defaultTickPadding
+	)
+	text.Font = font
+	text.Baseline = "middle"
+	switch c.Orient {
+	case TreeHorizontal:
+		if p.Node.Leaf() {
+			text.Pos = pos.Adjust(offset, 0)
+			text.Anchor = svg.AlignStart
+		} else {
+			text.Pos = pos.Adjust(-offset, 0)
+			text.Anchor = svg.AlignEnd
+		}
+	case TreeRadial:
+		var (
+			angle = p.X*180/math.Pi - 90
+			flip  = p.X > math.Pi
+			out   = p.Node.Leaf() == !flip
+		)
+		if flip {
+			angle -= 180
+		}
+		text.Pos = pos.Adjust(offset, 0)
+		text.Anchor = svg.AlignStart
+		if !out {
+			text.Pos = pos.Adjust(-offset, 0)
+			text.Anchor = svg.AlignEnd
+		}
+		if p.Depth > 0 {
+			text.Transform.Rotate(angle, pos.X, pos.Y)
+		}
+	default:
+		if p.Node.Leaf() {
+			text.Pos = pos.Adjust(0, offset+font.Size/2)
+		} else {
+			text.Pos = pos.Adjust(0, -offset-font.Size/2)
+		}
+		text.Anchor = svg.AlignMiddle
+	}
+	return text.AsElement()
+}
+
+func (c TreeChart) nodeColor(points []hierarchy.Point, i int) string {
+	if points[i].Node.Color != "" {
+		return points[i].Node.Color
+	}
+	if points[i].Node.Leaf() {
+		return "#999999"
+	}
+	return "#555555"
+}
+
+func (c TreeChart) radius() float64 {
+	if c.NodeRadius <= 0 {
+		return defaultNodeRadius
+	}
+	return c.NodeRadius
+}
@@ -0,0 +1,180 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/hierarchy"
+)
+
+const labelInset = 4
+
+type TreemapChart struct {
+	Chart
+	Root  *hierarchy.Node
+	Tile  hierarchy.Tiling
+	Ratio float64
+	Inner float64
+	Outer float64
+	Top   float64
+	Depth int
+}
+
+func (c TreemapChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c TreemapChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+	)
+	if c.Root == nil {
+		return canvas.AsElement()
+	}
+	t := hierarchy.Treemap{
+		Tile:  c.Tile,
+		Ratio: c.Ratio,
+		Inner: c.Inner,
+		Outer: c.Outer,
+		Top:   c.Top,
+	}
+	cells := t.Layout(c.Root, dim.W, dim.H)
+	plot.Append(nested(c.Chart, "treemap", cells, c.Depth))
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(branches(c.Chart, c.Root), dim))
+	return canvas.AsElement()
+}
+
+type IcicleChart struct {
+	Chart
+	Root       *hierarchy.Node
+	Horizontal bool
+	Depth      int
+}
+
+func (c IcicleChart) Render(w io.Writer) error {
+	return render(w, c.Element())
+}
+
+func (c IcicleChart) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		plot   = c.plot()
+		_, dim = c.area()
+		cells  []hierarchy.Cell
+	)
+	if c.Root == nil {
+		return canvas.AsElement()
+	}
+	if c.Horizontal {
+		cells = hierarchy.Partition(c.Root, dim.H, dim.W)
+		for i := range cells {
+			x0, x1 := cells[i].X0, cells[i].X1
+			cells[i].X0, cells[i].X1 = cells[i].Y0, cells[i].Y1
+			cells[i].Y0, cells[i].Y1 = x0, x1
+		}
+	} else {
+		cells = hierarchy.Partition(c.Root, dim.W, dim.H)
+	}
+	plot.Append(nested(c.Chart, "icicle", cells, c.Depth))
+	canvas.Append(plot.AsElement())
+	canvas.Append(c.legend(branches(c.Chart, c.Root), dim))
+	return canvas.AsElement()
+}
+
+func nested(c Chart, class string, cells []hierarchy.Cell, depth int) svg.Element {
+	var (
+		groups = make([]*svg.Group, len(cells))
+		font   = c.Font.OrDefault()
+		root   *svg.Group
+	)
+	for i, cell := range cells {
+		if depth > 0 && cell.Depth > depth {
+			continue
+		}
+		var (
+			grp  svg.Group
+			rect svg.Rect
+		)
+		grp.Class = append(grp.Class, "node")
+		grp.Data = []svg.Datum{
+			{Name: "name", Value: cell.Node.Name},
+			{Name: "value", Value: cell.Node.Sum()},
+			{Name: "depth", Value: cell.Depth},
+			{Name: "path", Value: hierarchy.Path(cells, i)},
+		}
+		rect.Pos = svg.NewPos(cell.X0, cell.Y0)
+		rect.Dim = svg.NewDim(cell.Width(), cell.Height())
+		rect.Fill = svg.NewFill(cellColor(c, cells, i))
+		rect.Stroke = svg.NewStroke("white", 1)
+		grp.Append(rect.AsElement())
+
+		if cell.Depth > 0 && cell.Width() > labelInset*2 && cell.Height() > font.Size {
+			var (
+				clip svg.ClipPath
+				area svg.Rect
+				text = svg.NewText(cell.Node.Name)
+			)
+			clip.Id = fmt.Sprintf("%s-clip-%d", class, i)
+			area.Pos = rect.Pos
+			area.Dim = rect.Dim
+			clip.Append(area.AsElement())
+			grp.Append(clip.AsElement())
+
+			text.Font = font
+			text.Pos = svg.NewPos(cell.X0+labelInset, cell.Y0+labelInset)
+			text.Baseline = "hanging"
+			text.Clip = clip.Id
+			grp.Append(text.AsElement())
+		}
+		groups[i] = &grp
+		if cell.Parent < 0 || groups[cell.Parent] == nil {
+			root = groups[i]
+			root.Class = append(root.Class, class)
+			continue
+		}
+		groups[cell.Parent].Append(groups[i].AsElement())
+	}
+	if root == nil {
+		return nil
+	}
+	return root.AsElement()
+}
+
+func cellColor(c Chart, cells []hierarchy.Cell, i int) string {
+	if cells[i].Node.Color != "" {
+		return cells[i].Node.Color
+	}
+	if cells[i].Depth == 0 {
+		return "#dddddd"
+	}
+	var (
+		branch = hierarchy.Ancestor(cells, i, 1)
+		parent = cells[cells[branch].Parent].Node
+		index  = branchIndex(parent, cells[branch].Node)
+	)
+	if index < 0 {
+		return "#dddddd"
+	}
+	return c.color(index, Series{Color: cells[branch].Node.Color})
+}
+
+func branches(c Chart, root *hierarchy.Node) []Entry {
+	var series []Series
+	for _, n := range root.Children {
+		series = append(series, Series{Name: n.Name, Color: n.Color})
+	}
+	return c.entries(series, ShapeRect)
+}
+
+func branchIndex(root, n *hierarchy.Node) int {
+	for i := range root.Children {
+		if root.Children[i] == n {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,201 @@
+package svg
+
+import (
+	"sort"
+	"strings"
+)
+
+type Declaration struct {
+	Property  string
+	Values    []string
+	Important bool
+}
+
+func Decl(prop string, values ...string) Declaration {
+	return Declaration{
+		Property: prop,
+		Values:   values,
+	}
+}
+
+func (d Declaration) String() string {
+	var buf strings.Builder
+	buf.WriteString(d.Property)
+	buf.WriteString(": ")
+	buf.WriteString(strings.Join(d.Values, " "))
+	if d.Important {
+		buf.WriteString(" !important")
+	}
+	return buf.String()
+}
+
+type Rule struct {
+	Selectors    []string
+	Declarations []Declaration
+}
+
+func NewRule(selector string, decls ...Declaration) Rule {
+	var list []string
+	for _, s := range strings.Split(selector, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return Rule{
+		Selectors:    list,
+		Declarations: decls,
+	}
+}
+
+func (r Rule) writeCSS(buf *strings.Builder, indent string) {
+	if len(r.Selectors) == 0 || len(r.Declarations) == 0 {
+		return
+	}
+	writeBlock(buf, indent, strings.Join(r.Selectors, ", "), r.Declarations)
+}
+
+type Media struct {
+	Query string
+	Rules []Rule
+}
+
+func (m Media) writeCSS(buf *strings.Builder, indent string) {
+	if len(m.Rules) == 0 {
+		return
+	}
+	buf.WriteString(indent)
+	buf.WriteString("@media ")
+	buf.WriteString(m.Query)
+	buf.WriteString(" {\n")
+	for _, r := range m.Rules {
+		r.writeCSS(buf, indent+cssIndent)
+	}
+	buf.WriteString(indent)
+	buf.WriteString("}\n")
+}
+
+type FontFace struct {
+	Family       string
+	Src          []string
+	Declarations []Declaration
+}
+
+func (f FontFace) writeCSS(buf *strings.Builder, indent string) {
+	decls := []Declaration{Decl("font-family", quoteCSS(f.Family))}
+	if len(f.Src) > 0 {
+		decls = append(decls, Decl("src", strings.Join(f.Src, ", ")))
+	}
+	decls = append(decls, f.Declarations...)
+	writeBlock(buf, indent, "@font-face", decls)
+}
+
+type Keyframe struct {
+	Offset       string
+	Declarations []Declaration
+}
+
+type Keyframes struct {
+	Name   string
+	Frames []Keyframe
+}
+
+func (k Keyframes) writeCSS(buf *strings.Builder, indent string) {
+	buf.WriteString(indent)
+	buf.WriteString("@keyframes ")
+	buf.WriteString(k.Name)
+	buf.WriteString(" {\n")
+	for _, f := range k.Frames {
+		writeBlock(buf, indent+cssIndent, f.Offset, f.Declarations)
+	}
+	buf.WriteString(indent)
+	buf.WriteString("}\n")
+}
+
+const cssIndent = "  "
+
+type Statement interface {
+	writeCSS(*strings.Builder, string)
+}
+
+type StyleSheet struct {
+	items []Statement
+}
+
+func NewStyleSheet() *StyleSheet {
+	return &StyleSheet{}
+}
+
+func (s *StyleSheet) Rule(selector string, decls ...Declaration) *StyleSheet {
+	return s.Add(NewRule(selector, decls...))
+}
+
+func (s *StyleSheet) Media(query string, rules ...Rule) *StyleSheet {
+	return s.Add(Media{Query: query, Rules: rules})
+}
+
+func (s *StyleSheet) FontFace(family string, src []string, decls ...Declaration) *StyleSheet {
+	return s.Add(FontFace{Family: family, Src: src, Declarations: decls})
+}
+
+func (s *StyleSheet) Keyframes(name string, frames ...Keyframe) *StyleSheet {
+	return s.Add(Keyframes{Name: name, Frames: frames})
+}
+
+func (s *StyleSheet) Add(items ...Statement) *StyleSheet {
+	s.items = append(s.items, items...)
+	return s
+}
+
+func (s *StyleSheet) Len() int {
+	return len(s.items)
+}
+
+func (s *StyleSheet) String() string {
+	var buf strings.Builder
+	for _, i := range s.items {
+		i.writeCSS(&buf, "")
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+func (s *StyleSheet) Style() Style {
+	return Style{Sheet: s}
+}
+
+func writeBlock(buf *strings.Builder, indent, selector string, decls []Declaration) {
+	buf.WriteString(indent)
+	buf.WriteString(selector)
+	buf.WriteString(" {\n")
+	for _, d := range decls {
+		buf.WriteString(indent)
+		buf.WriteString(cssIndent)
+		buf.WriteString(d.String())
+		buf.WriteString(";\n")
+	}
+	buf.WriteString(indent)
+	buf.WriteString("}\n")
+}
+
+func quoteCSS(str string) string {
+	if strings.HasPrefix(str, "'") || strings.HasPrefix(str, "\"") {
+		return str
+	}
+	return "'" + str + "'"
+}
+
+func inlineStyle(styles map[string][]string) string {
+	keys := make([]string, 0, len(styles))
+	for k := range styles {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var list []string
+	for _, k := range keys {
+		if len(styles[k]) == 0 {
+			continue
+		}
+		list = append(list, Decl(k, styles[k]...).String())
+	}
+	return strings.Join(list, "; ")
+}
@@ -0,0 +1,331 @@
+package svg
+
+import (
+	"strings"
+)
+
+type scope struct {
+	parent  *scope
+	renames map[string]string
+}
+
+func (s *scope) lookup(id string) (string, bool) {
+	for ; s != nil; s = s.parent {
+		if next, ok := s.renames[id]; ok {
+			return next, true
+		}
+	}
+	return "", false
+}
+
+type resource struct {
+	Element
+	scope *scope
+}
+
+type document struct {
+	Writer
+	buf strings.Builder
+
+	mode    StyleMode
+	scopes  map[*node]*scope
+	stack   []*scope
+	ids     map[*node]string
+	alias   map[string]string
+	skip    map[*node]bool
+	author  map[*node]bool
+	defs    []resource
+	current *node
+	blank   *node
+	started bool
+	offset  int
+
+	classes map[string]string
+	rules   []Rule
+}
+
+func renderDocument(w Writer, s *SVG) {
+	d := analyze(s)
+	if d.mode == StyleClasses {
+		d.Writer = &d.buf
+	} else {
+		d.Writer = w
+	}
+	if !s.OmitProlog {
+		d.WriteString(prolog)
+	}
+	s.Render(d)
+	if d.mode != StyleClasses {
+		return
+	}
+	str := d.buf.String()
+	if len(d.rules) == 0 {
+		w.WriteString(str)
+		return
+	}
+	style := Style{Sheet: NewStyleSheet()}
+	for _, r := range d.rules {
+		style.Sheet.Add(r)
+	}
+	w.WriteString(str[:d.offset])
+	style.Render(w)
+	w.WriteString(str[d.offset:])
+}
+
+func analyze(s *SVG) *document {
+	d := document{
+		mode:    s.StyleMode,
+		scopes:  make(map[*node]*scope),
+		ids:     make(map[*node]string),
+		alias:   make(map[string]string),
+		skip:    make(map[*node]bool),
+		author:  make(map[*node]bool),
+		classes: make(map[string]string),
+	}
+	d.resolve(s, nil, NewRegistry())
+	d.hoist(s)
+	if d.mode != StyleAttributes {
+		d.targets(s)
+	}
+	return &d
+}
+
+func (d *document) resolve(root *SVG, parent *scope, reg *Registry) {
+	var (
+		sc     = &scope{parent: parent, renames: make(map[string]string)}
+		nested []*SVG
+	)
+	d.scopes[&root.node] = sc
+	walkScope(root, func(e Element) {
+		if e == Element(root) && parent != nil {
+			return
+		}
+		if s, ok := e.(*SVG); ok && s != root {
+			nested = append(nested, s)
+		}
+		n, ok := e.(identified)
+		if !ok || n.identity().Id == "" {
+			return
+		}
+		var (
+			id   = n.identity().Id
+			next = reg.Register(id)
+		)
+		if _, ok := sc.renames[id]; !ok {
+			sc.renames[id] = next
+		}
+		if next != id {
+			d.ids[n.identity()] = next
+		}
+		if resourceKind(e) != "" {
+			d.defs = append(d.defs, resource{Element: e, scope: sc})
+			d.skip[n.identity()] = true
+		}
+	})
+	for _, s := range nested {
+		d.resolve(s, sc, reg)
+	}
+}
+
+func (d *document) hoist(root *SVG) {
+	Walk(root, func(e Element, _ int) error {
+		defs, ok := e.(*Defs)
+		if !ok || defs.Id != "" {
+			return nil
+		}
+		for _, c := range defs.Children() {
+			if c != nil && !d.skipped(c) {
+				return nil
+			}
+		}
+		d.skip[defs.identity()] = true
+		return nil
+	})
+	var (
+		seen = make(map[string]string)
+		list []resource
+	)
+	for _, r := range d.defs {
+		var (
+			n   = r.Element.(identified).identity()
+			id  = d.id(n)
+			key = d.structure(r)
+		)
+		if first, ok := seen[key]; ok {
+			d.alias[id] = first
+			continue
+		}
+		seen[key] = id
+		list = append(list, r)
+	}
+	d.defs = list
+}
+
+func (d *document) structure(r resource) string {
+	var (
+		tmp = *d
+		n   = r.Element.(identified).identity()
+	)
+	tmp.buf = strings.Builder{}
+	tmp.Writer = &tmp.buf
+	tmp.stack = []*scope{r.scope}
+	tmp.blank = n
+	tmp.started = true
+	tmp.mode = StyleAttributes
+	r.Element.Render(&tmp)
+	return tmp.buf.String()
+}
+
+func (d *document) targets(root *SVG) {
+	var groups []selector
+	Walk(root, func(e Element, _ int) error {
+		s, ok := e.(*Style)
+		if !ok {
+			return nil
+		}
+		for _, str := range authorSelectors(s) {
+			if sel, err := parseSelector(str); err == nil {
+				groups = append(groups, sel...)
+			}
+		}
+		return nil
+	})
+	if len(groups) == 0 {
+		return
+	}
+	for _, e := range match(root, groups, false) {
+		if n, ok := e.(identified); ok {
+			d.author[n.identity()] = true
+		}
+	}
+}
+
+func (d *document) skipped(e Element) bool {
+	if _, ok := e.(*Defs); !ok && resourceKind(e) == "" {
+		return false
+	}
+	return d.skip[e.(identified).identity()]
+}
+
+func (d *document) id(n *node) string {
+	if id, ok := d.ids[n]; ok {
+		return id
+	}
+	return n.Id
+}
+
+func (d *document) scope() *scope {
+	if len(d.stack) == 0 {
+		return nil
+	}
+	return d.stack[len(d.stack)-1]
+}
+
+func (d *document) open(name string, attrs []string) []string {
+	n := d.current
+	d.current = nil
+	if name == "svg" && n != nil {
+		sc, ok := d.scopes[n]
+		if !ok {
+			sc = d.scope()
+		}
+		d.stack = append(d.stack, sc)
+	}
+	var (
+		list = make([]string, 0, len(attrs))
+		sc   = d.scope()
+	)
+	for _, a := range attrs {
+		attr, value, ok := splitAttr(a)
+		if !ok {
+			list = append(list, a)
+			continue
+		}
+		switch {
+		case attr == "id" && n != nil:
+			if n == d.blank {
+				continue
+			}
+			value = d.id(n)
+		case attr == "href" || attr == "xlink:href":
+			if strings.HasPrefix(value, "#") {
+				value = "#" + d.reference(sc, value[1:])
+			}
+		default:
+			value = d.references(sc, value)
+		}
+		list = append(list, appendString(attr, value))
+	}
+	if d.mode != StyleAttributes && (n == nil || !d.author[n]) {
+		list = d.transform(list)
+	}
+	return list
+}
+
+func (d *document) opened(name string) {
+	if d.started || name != "svg" {
+		return
+	}
+	d.started = true
+	d.offset = d.buf.Len()
+	if len(d.defs) == 0 {
+		return
+	}
+	writeOpenElement(d, "defs", false, nil)
+	for _, r := range d.defs {
+		d.stack = append(d.stack, r.scope)
+		r.Element.Render(d)
+		d.stack = d.stack[:len(d.stack)-1]
+	}
+	writeCloseElement(d, "defs")
+}
+
+func (d *document) closed(name string) {
+	if name == "svg" && len(d.stack) > 0 {
+		d.stack = d.stack[:len(d.stack)-1]
+	}
+}
+
+func (d *document) reference(sc *scope, id string) string {
+	if next, ok := sc.lookup(id); ok {
+		id = next
+	}
+	if next, ok := d.alias[id]; ok {
+		id = next
+	}
+	return id
+}
+
+func (d *document) references(sc *scope, value string) string {
+	const prefix = "url(#"
+	if !strings.Contains(value, prefix) {
+		return value
+	}
+	var buf strings.Builder
+	for {
+		i := strings.Index(value, prefix)
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(value[i:], ')')
+		if j < 0 {
+			break
+		}
+		buf.WriteString(value[:i+len(prefix)])
+		buf.WriteString(d.reference(sc, value[i+len(prefix):i+j]))
+		buf.WriteByte(')')
+		value = value[i+j+1:]
+	}
+	buf.WriteString(value)
+	return buf.String()
+}
+
+func walkScope(root *SVG, fn func(Element)) {
+	Walk(root, func(e Element, depth int) error {
+		fn(e)
+		if _, ok := e.(*SVG); ok && depth > 0 {
+			return ErrSkip
+		}
+		return nil
+	})
+}
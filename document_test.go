@@ -0,0 +1,149 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderDocument(t *testing.T) {
+	var (
+		child1 = testChart("red")
+		child2 = testChart("red")
+		child3 = testChart("blue")
+		before = renderString(child1)
+		root   = NewSVG()
+	)
+	root.Append(child1)
+	root.Append(child2)
+	root.Append(child3)
+
+	str := renderString(&root)
+	if n := strings.Count(str, "<linearGradient"); n != 2 {
+		t.Errorf("expected 2 gradients after deduplication, got %d", n)
+	}
+	if n := strings.Count(str, "<defs>"); n != 1 {
+		t.Errorf("expected a single defs block, got %d", n)
+	}
+	for _, want := range []string{`id="grad"`, `id="grad-3"`, `id="box"`, `id="box-2"`, `id="box-3"`, `fill="url(#grad-3)"`} {
+		if !strings.Contains(str, want) {
+			t.Errorf("%s not found in %s", want, str)
+		}
+	}
+	if strings.Contains(str, "grad-2") {
+		t.Errorf("duplicate gradient still referenced in %s", str)
+	}
+	if again := renderString(&root); again != str {
+		t.Errorf("rendering twice gives different output")
+	}
+	child1.OmitProlog = false
+	if after := renderString(child1); after != before {
+		t.Errorf("rendering modified the tree: want %s, got %s", before, after)
+	}
+	if e, _ := root.QuerySelector("#box-2"); e != nil {
+		t.Errorf("rendering modified ids of the tree")
+	}
+}
+
+type parts []string
+
+func (p parts) Render(w Writer) {
+	w.WriteString(strings.Join(p, ""))
+}
+
+func TestRenderValueElement(t *testing.T) {
+	var (
+		root = NewSVG()
+		defs Defs
+		grad Linear
+	)
+	grad.Id = "grad"
+	defs.Append(grad.AsElement())
+	defs.Append(parts{"<g/>"})
+	root.OmitProlog = true
+	root.Append(defs.AsElement())
+	root.Append(parts{"<rect/>", "<circle/>"})
+
+	str := renderString(&root)
+	for _, want := range []string{"<rect/><circle/>", "<g/>", `id="grad"`} {
+		if !strings.Contains(str, want) {
+			t.Errorf("%s not found in %s", want, str)
+		}
+	}
+}
+
+func testChart(color string) *SVG {
+	var (
+		chart = NewSVG()
+		defs  Defs
+		grad  Linear
+		stop  = NewStop(0, color)
+		rect  Rect
+	)
+	grad.Id = "grad"
+	grad.Append(stop.AsElement())
+	defs.Append(grad.AsElement())
+	chart.Append(defs.AsElement())
+
+	rect.Id = "box"
+	rect.Fill = NewFill(UrlFor(grad.Id))
+	chart.Append(rect.AsElement())
+	return &chart
+}
+
+func renderString(e Element) string {
+	var buf strings.Builder
+	e.Render(&buf)
+	return buf.String()
+}
+
+func TestRenderStyleMode(t *testing.T) {
+	data := []struct {
+		Mode    StyleMode
+		Want    []string
+		Without []string
+	}{
+		{
+			Mode:    StyleAttributes,
+			Want:    []string{`stroke="black"`, `stroke="red"`},
+			Without: []string{`class="style-`, `style=`},
+		},
+		{
+			Mode:    StyleInline,
+			Want:    []string{`style="stroke: black; stroke-width: 2px"`, `stroke="red" id="mark"`},
+			Without: []string{`class="style-`},
+		},
+		{
+			Mode:    StyleClasses,
+			Want:    []string{`class="style-1"`, ".style-1 {", `stroke="red" id="mark"`},
+			Without: []string{`stroke="black"`, `style="`},
+		},
+	}
+	for _, d := range data {
+		var (
+			root  = NewSVG()
+			line  = NewLine(NewPos(0, 0), NewPos(10, 10))
+			mark  = NewLine(NewPos(0, 10), NewPos(10, 0))
+			style = NewStyleSheet().Rule("#mark", Decl("stroke", "blue")).Style()
+		)
+		root.OmitProlog = true
+		root.StyleMode = d.Mode
+		line.Stroke = NewStroke("black", 2)
+		mark.Id = "mark"
+		mark.Stroke = NewStroke("red", 0)
+		root.Append(style.AsElement())
+		root.Append(line.AsElement())
+		root.Append(mark.AsElement())
+
+		str := renderString(&root)
+		for _, want := range d.Want {
+			if !strings.Contains(str, want) {
+				t.Errorf("mode %d: %s not found in %s", d.Mode, want, str)
+			}
+		}
+		for _, other := range d.Without {
+			if strings.Contains(str, other) {
+				t.Errorf("mode %d: unexpected %s in %s", d.Mode, other, str)
+			}
+		}
+	}
+}
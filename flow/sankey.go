@@ -0,0 +1,702 @@
+package flow
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/chart"
+	"github.com/midbel/svg/layout"
+)
+
+var (
+	ErrCycle   = errors.New("cycle detected")
+	ErrUnknown = errors.New("unknown node")
+)
+
+const (
+	defaultWidth       = 800
+	defaultHeight      = 600
+	defaultNodeWidth   = 24
+	defaultNodePadding = 8
+	defaultIterations  = 6
+	defaultOpacity     = 0.5
+	labelOffset        = 6
+)
+
+type Align int
+
+const (
+	AlignJustify Align = iota
+	AlignLeft
+	AlignRight
+	AlignCenter
+)
+
+type Node struct {
+	Name  string
+	Color string
+}
+
+type Link struct {
+	Source string
+	Target string
+	Value  float64
+}
+
+type Box struct {
+	Node
+	Value float64
+	Layer int
+	X0    float64
+	Y0    float64
+	X1    float64
+	Y1    float64
+	In    []int
+	Out   []int
+}
+
+func (b Box) Width() float64 {
+	return b.X1 - b.X0
+}
+
+func (b Box) Height() float64 {
+	return b.Y1 - b.Y0
+}
+
+type Ribbon struct {
+	Link
+	From  int
+	To    int
+	Width float64
+	Y0    float64
+	Y1    float64
+}
+
+type Graph struct {
+	Nodes []Box
+	Links []Ribbon
+}
+
+type Sankey struct {
+	Nodes       []Node
+	Links       []Link
+	Width       float64
+	Height      float64
+	Padding     layout.Padding
+	NodeWidth   float64
+	NodePadding float64
+	Iterations  int
+	Align       Align
+	Fixed       bool
+	Colors      []string
+	Opacity     float64
+	Font        svg.Font
+	OmitLabels  bool
+}
+
+func (s Sankey) Render(w io.Writer) error {
+	g, err := s.Layout()
+	if err != nil {
+		return err
+	}
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := s.render(g)
+	el.Render(ws)
+	return nil
+}
+
+func (s Sankey) Element() svg.Element {
+	g, _ := s.Layout()
+	return s.render(g)
+}
+
+func (s Sankey) Layout() (Graph, error) {
+	var (
+		g   Graph
+		err error
+	)
+	if g, err = s.graph(); err != nil {
+		return g, err
+	}
+	if len(g.Nodes) == 0 {
+		return g, nil
+	}
+	columns, err := s.layers(&g)
+	if err != nil {
+		return g, err
+	}
+	var (
+		width, height = s.inner()
+		py            = s.breadths(&g, columns, height)
+		iter          = s.Iterations
+	)
+	if iter <= 0 {
+		iter = defaultIterations
+	}
+	kx := 0.0
+	if len(columns) > 1 {
+		kx = (width - s.nodeWidth()) / float64(len(columns)-1)
+	}
+	for i := range g.Nodes {
+		b := &g.Nodes[i]
+		b.X0 = float64(b.Layer) * kx
+		b.X1 = b.X0 + s.nodeWidth()
+	}
+	l := relaxer{
+		Graph:   &g,
+		columns: columns,
+		height:  height,
+		padding: py,
+		fixed:   s.Fixed,
+	}
+	l.reorder()
+	for i := 0; i < iter; i++ {
+		var (
+			alpha = math.Pow(0.99, float64(i))
+			beta  = math.Max(1-alpha, float64(i+1)/float64(iter))
+		)
+		l.relaxRightToLeft(alpha, beta)
+		l.relaxLeftToRight(alpha, beta)
+	}
+	l.reorder()
+	for i := range g.Nodes {
+		var (
+			b  = &g.Nodes[i]
+			y0 = b.Y0
+			y1 = b.Y0
+		)
+		for _, j := range b.Out {
+			g.Links[j].Y0 = y0 + g.Links[j].Width/2
+			y0 += g.Links[j].Width
+		}
+		for _, j := range b.In {
+			g.Links[j].Y1 = y1 + g.Links[j].Width/2
+			y1 += g.Links[j].Width
+		}
+	}
+	return g, nil
+}
+
+func (s Sankey) graph() (Graph, error) {
+	var (
+		g     Graph
+		index = make(map[string]int)
+	)
+	add := func(n Node) int {
+		if i, ok := index[n.Name]; ok {
+			return i
+		}
+		index[n.Name] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, Box{Node: n})
+		return len(g.Nodes) - 1
+	}
+	for _, n := range s.Nodes {
+		add(n)
+	}
+	for _, k := range s.Links {
+		if k.Value <= 0 {
+			continue
+		}
+		if len(s.Nodes) > 0 {
+			if _, ok := index[k.Source]; !ok {
+				return g, fmt.Errorf("%w: %s", ErrUnknown, k.Source)
+			}
+			if _, ok := index[k.Target]; !ok {
+				return g, fmt.Errorf("%w: %s", ErrUnknown, k.Target)
+			}
+		}
+		r := Ribbon{
+			Link: k,
+			From: add(Node{Name: k.Source}),
+			To:   add(Node{Name: k.Target}),
+		}
+		if r.From == r.To {
+			return g, fmt.Errorf("%w: %s", ErrCycle, k.Source)
+		}
+		g.Links = append(g.Links, r)
+		g.Nodes[r.From].Out = append(g.Nodes[r.From].Out, len(g.Links)-1)
+		g.Nodes[r.To].In = append(g.Nodes[r.To].In, len(g.Links)-1)
+	}
+	for i := range g.Nodes {
+		var (
+			b       = &g.Nodes[i]
+			in, out float64
+		)
+		for _, j := range b.In {
+			in += g.Links[j].Value
+		}
+		for _, j := range b.Out {
+			out += g.Links[j].Value
+		}
+		b.Value = math.Max(in, out)
+	}
+	return g, nil
+}
+
+func (s Sankey) layers(g *Graph) ([][]int, error) {
+	var (
+		depth  = make([]int, len(g.Nodes))
+		height = make([]int, len(g.Nodes))
+		walk   = func(list []int, next func(int) []int, set func(int, int)) error {
+			for x := 0; len(list) > 0; x++ {
+				if x > len(g.Nodes) {
+					return ErrCycle
+				}
+				var (
+					seen = make(map[int]bool)
+					tmp  []int
+				)
+				for _, i := range list {
+					set(i, x)
+					for _, j := range next(i) {
+						if !seen[j] {
+							seen[j] = true
+							tmp = append(tmp, j)
+						}
+					}
+				}
+				list = tmp
+			}
+			return nil
+		}
+		all = make([]int, len(g.Nodes))
+	)
+	for i := range all {
+		all[i] = i
+	}
+	targets := func(i int) []int {
+		var list []int
+		for _, j := range g.Nodes[i].Out {
+			list = append(list, g.Links[j].To)
+		}
+		return list
+	}
+	sources := func(i int) []int {
+		var list []int
+		for _, j := range g.Nodes[i].In {
+			list = append(list, g.Links[j].From)
+		}
+		return list
+	}
+	if err := walk(all, targets, func(i, x int) { depth[i] = x }); err != nil {
+		return nil, err
+	}
+	if err := walk(all, sources, func(i, x int) { height[i] = x }); err != nil {
+		return nil, err
+	}
+	var count int
+	for i := range depth {
+		if depth[i] >= count {
+			count = depth[i] + 1
+		}
+	}
+	for i := range g.Nodes {
+		b := &g.Nodes[i]
+		switch s.Align {
+		case AlignLeft:
+			b.Layer = depth[i]
+		case AlignRight:
+			b.Layer = count - 1 - height[i]
+		case AlignCenter:
+			switch {
+			case len(b.In) > 0:
+				b.Layer = depth[i]
+			case len(b.Out) > 0:
+				b.Layer = count
+				for _, j := range targets(i) {
+					if depth[j]-1 < b.Layer {
+						b.Layer = depth[j] - 1
+					}
+				}
+			default:
+				b.Layer = 0
+			}
+		default:
+			b.Layer = depth[i]
+			if len(b.Out) == 0 {
+				b.Layer = count - 1
+			}
+		}
+		if b.Layer >= count {
+			b.Layer = count - 1
+		}
+		if b.Layer < 0 {
+			b.Layer = 0
+		}
+	}
+	columns := make([][]int, count)
+	for i := range g.Nodes {
+		columns[g.Nodes[i].Layer] = append(columns[g.Nodes[i].Layer], i)
+	}
+	return columns, nil
+}
+
+func (s Sankey) breadths(g *Graph, columns [][]int, height float64) float64 {
+	var (
+		py  = s.nodePadding()
+		ky  = math.Inf(1)
+		big int
+	)
+	for _, col := range columns {
+		if len(col) > big {
+			big = len(col)
+		}
+	}
+	if big > 1 {
+		py = math.Min(py, height/float64(big-1)/2)
+	}
+	for _, col := range columns {
+		var sum float64
+		for _, i := range col {
+			sum += g.Nodes[i].Value
+		}
+		if sum > 0 {
+			ky = math.Min(ky, (height-float64(len(col)-1)*py)/sum)
+		}
+	}
+	if math.IsInf(ky, 0) {
+		ky = 0
+	}
+	for _, col := range columns {
+		var y float64
+		for _, i := range col {
+			b := &g.Nodes[i]
+			b.Y0 = y
+			b.Y1 = y + b.Value*ky
+			y = b.Y1 + py
+		}
+		gap := (height - y + py) / float64(len(col)+1)
+		for k, i := range col {
+			g.Nodes[i].Y0 += gap * float64(k+1)
+			g.Nodes[i].Y1 += gap * float64(k+1)
+		}
+	}
+	for i := range g.Links {
+		g.Links[i].Width = g.Links[i].Value * ky
+	}
+	return py
+}
+
+func (s Sankey) inner() (float64, float64) {
+	var (
+		width, height = s.size()
+		pad           = s.Padding
+	)
+	return width - pad.Left - pad.Right, height - pad.Top - pad.Bottom
+}
+
+func (s Sankey) size() (float64, float64) {
+	width, height := s.Width, s.Height
+	if width <= 0 {
+		width = defaultWidth
+	}
+	if height <= 0 {
+		height = defaultHeight
+	}
+	return width, height
+}
+
+func (s Sankey) nodeWidth() float64 {
+	if s.NodeWidth <= 0 {
+		return defaultNodeWidth
+	}
+	return s.NodeWidth
+}
+
+func (s Sankey) nodePadding() float64 {
+	if s.NodePadding <= 0 {
+		return defaultNodePadding
+	}
+	return s.NodePadding
+}
+
+func (s Sankey) color(i int, b Box) string {
+	if b.Color != "" {
+		return b.Color
+	}
+	colors := s.Colors
+	if len(colors) == 0 {
+		colors = chart.Palette
+	}
+	return colors[i%len(colors)]
+}
+
+func (s Sankey) opacity() float64 {
+	if s.Opacity <= 0 {
+		return defaultOpacity
+	}
+	return s.Opacity
+}
+
+func (s Sankey) render(g Graph) svg.Element {
+	var (
+		width, height = s.size()
+		canvas        = svg.NewSVG()
+		defs          svg.Defs
+		links, nodes  svg.Group
+		labels        svg.Group
+		area          svg.Group
+		inner, _      = s.inner()
+		font          = s.Font.OrDefault()
+	)
+	canvas.Dim = svg.NewDim(width, height)
+	area.Class = append(area.Class, "sankey")
+	area.Transform = svg.Translate(s.Padding.Left, s.Padding.Top)
+	links.Class = append(links.Class, "links")
+	nodes.Class = append(nodes.Class, "nodes")
+	labels.Class = append(labels.Class, "labels")
+	for i, k := range g.Links {
+		var (
+			src  = g.Nodes[k.From]
+			dst  = g.Nodes[k.To]
+			grad svg.Linear
+			path = k.Path(src, dst)
+		)
+		grad.Id = fmt.Sprintf("sankey-link-%d", i)
+		grad.Units = "userSpaceOnUse"
+		grad.Pos1 = svg.NewPos(src.X1, 0)
+		grad.Pos2 = svg.NewPos(dst.X0, 0)
+		for j, b := range []int{k.From, k.To} {
+			stop := svg.NewStop(float64(j), s.color(b, g.Nodes[b]))
+			grad.Append(stop.AsElement())
+		}
+		defs.Append(grad.AsElement())
+
+		path.Class = append(path.Class, "link")
+		path.Fill = svg.NewFill("none")
+		path.Stroke = svg.NewStroke(svg.UrlFor(grad.Id), math.Max(1, k.Width))
+		path.Stroke.Opacity = s.opacity()
+		path.Data = []svg.Datum{
+			{Name: "source", Value: k.Source},
+			{Name: "target", Value: k.Target},
+			{Name: "value", Value: k.Value},
+		}
+		links.Append(path.AsElement())
+	}
+	for i, b := range g.Nodes {
+		var rect svg.Rect
+		rect.Pos = svg.NewPos(b.X0, b.Y0)
+		rect.Dim = svg.NewDim(b.Width(), b.Height())
+		rect.Fill = svg.NewFill(s.color(i, b))
+		rect.Class = append(rect.Class, "node")
+		rect.Data = []svg.Datum{
+			{Name: "name", Value: b.Name},
+			{Name: "value", Value: b.Value},
+			{Name: "layer", Value: b.Layer},
+		}
+		nodes.Append(rect.AsElement())
+		if s.OmitLabels {
+			continue
+		}
+		text := svg.NewText(b.Name)
+		text.Font = font
+		text.Baseline = "middle"
+		if b.X0 < inner/2 {
+			text.Pos = svg.NewPos(b.X1+labelOffset, (b.Y0+b.Y1)/2)
+			text.Anchor = svg.AlignStart
+		} else {
+			text.Pos = svg.NewPos(b.X0-labelOffset, (b.Y0+b.Y1)/2)
+			text.Anchor = svg.AlignEnd
+		}
+		labels.Append(text.AsElement())
+	}
+	area.Append(defs.AsElement())
+	area.Append(links.AsElement())
+	area.Append(nodes.AsElement())
+	area.Append(labels.AsElement())
+	canvas.Append(area.AsElement())
+	return canvas.AsElement()
+}
+
+func (r Ribbon) Path(src, dst Box) svg.Path {
+	var (
+		path svg.Path
+		mid  = (src.X1 + dst.X0) / 2
+	)
+	path.AbsMoveTo(svg.NewPos(src.X1, r.Y0))
+	path.AbsCubicCurve(svg.NewPos(dst.X0, r.Y1), svg.NewPos(mid, r.Y0), svg.NewPos(mid, r.Y1))
+	return path
+}
+
+type relaxer struct {
+	*Graph
+	columns [][]int
+	height  float64
+	padding float64
+	fixed   bool
+}
+
+func (r relaxer) relaxLeftToRight(alpha, beta float64) {
+	for _, col := range r.columns[1:] {
+		for _, i := range col {
+			var (
+				dst  = &r.Nodes[i]
+				y, w float64
+			)
+			for _, j := range dst.In {
+				var (
+					k = r.Links[j]
+					v = k.Value * float64(dst.Layer-r.Nodes[k.From].Layer)
+				)
+				y += r.targetTop(k.From, i) * v
+				w += v
+			}
+			if w <= 0 {
+				continue
+			}
+			dy := (y/w - dst.Y0) * alpha
+			dst.Y0 += dy
+			dst.Y1 += dy
+			r.reorderNode(i)
+		}
+		r.sortColumn(col)
+		r.resolve(col, beta)
+	}
+}
+
+func (r relaxer) relaxRightToLeft(alpha, beta float64) {
+	for c := len(r.columns) - 2; c >= 0; c-- {
+		col := r.columns[c]
+		for _, i := range col {
+			var (
+				src  = &r.Nodes[i]
+				y, w float64
+			)
+			for _, j := range src.Out {
+				var (
+					k = r.Links[j]
+					v = k.Value * float64(r.Nodes[k.To].Layer-src.Layer)
+				)
+				y += r.sourceTop(i, k.To) * v
+				w += v
+			}
+			if w <= 0 {
+				continue
+			}
+			dy := (y/w - src.Y0) * alpha
+			src.Y0 += dy
+			src.Y1 += dy
+			r.reorderNode(i)
+		}
+		r.sortColumn(col)
+		r.resolve(col, beta)
+	}
+}
+
+func (r relaxer) sortColumn(col []int) {
+	if r.fixed {
+		return
+	}
+	sort.SliceStable(col, func(i, j int) bool {
+		return r.Nodes[col[i]].Y0 < r.Nodes[col[j]].Y0
+	})
+}
+
+func (r relaxer) resolve(col []int, alpha float64) {
+	if len(col) == 0 {
+		return
+	}
+	var (
+		i   = len(col) >> 1
+		mid = r.Nodes[col[i]]
+	)
+	r.bottomToTop(col, mid.Y0-r.padding, i-1, alpha)
+	r.topToBottom(col, mid.Y1+r.padding, i+1, alpha)
+	r.bottomToTop(col, r.height, len(col)-1, alpha)
+	r.topToBottom(col, 0, 0, alpha)
+}
+
+func (r relaxer) topToBottom(col []int, y float64, i int, alpha float64) {
+	for ; i < len(col); i++ {
+		b := &r.Nodes[col[i]]
+		if dy := (y - b.Y0) * alpha; dy > 1e-6 {
+			b.Y0 += dy
+			b.Y1 += dy
+		}
+		y = b.Y1 + r.padding
+	}
+}
+
+func (r relaxer) bottomToTop(col []int, y float64, i int, alpha float64) {
+	for ; i >= 0; i-- {
+		b := &r.Nodes[col[i]]
+		if dy := (b.Y1 - y) * alpha; dy > 1e-6 {
+			b.Y0 -= dy
+			b.Y1 -= dy
+		}
+		y = b.Y0 - r.padding
+	}
+}
+
+func (r relaxer) reorder() {
+	for i := range r.Nodes {
+		r.sortLinks(r.Nodes[i].Out, true)
+		r.sortLinks(r.Nodes[i].In, false)
+	}
+}
+
+func (r relaxer) reorderNode(i int) {
+	for _, j := range r.Nodes[i].In {
+		r.sortLinks(r.Nodes[r.Links[j].From].Out, true)
+	}
+	for _, j := range r.Nodes[i].Out {
+		r.sortLinks(r.Nodes[r.Links[j].To].In, false)
+	}
+}
+
+func (r relaxer) sortLinks(list []int, target bool) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := r.Links[list[i]], r.Links[list[j]]
+		if target {
+			return r.Nodes[a.To].Y0 < r.Nodes[b.To].Y0
+		}
+		return r.Nodes[a.From].Y0 < r.Nodes[b.From].Y0
+	})
+}
+
+func (r relaxer) targetTop(src, dst int) float64 {
+	var (
+		s = r.Nodes[src]
+		y = s.Y0 - float64(len(s.Out)-1)*r.padding/2
+	)
+	for _, j := range s.Out {
+		if r.Links[j].To == dst {
+			break
+		}
+		y += r.Links[j].Width + r.padding
+	}
+	for _, j := range r.Nodes[dst].In {
+		if r.Links[j].From == src {
+			break
+		}
+		y -= r.Links[j].Width
+	}
+	return y
+}
+
+func (r relaxer) sourceTop(src, dst int) float64 {
+	var (
+		d = r.Nodes[dst]
+		y = d.Y0 - float64(len(d.In)-1)*r.padding/2
+	)
+	for _, j := range d.In {
+		if r.Links[j].From == src {
+			break
+		}
+		y += r.Links[j].Width + r.padding
+	}
+	for _, j := range r.Nodes[src].Out {
+		if r.Links[j].To == dst {
+			break
+		}
+		y -= r.Links[j].Width
+	}
+	return y
+}
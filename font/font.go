@@ -0,0 +1,344 @@
+package font
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported font format")
+	ErrMalformed   = errors.New("malformed font")
+	ErrMissing     = errors.New("missing table")
+)
+
+const (
+	tagTrueType   = 0x00010000
+	tagTrue       = 0x74727565
+	tagOpenType   = 0x4f54544f
+	tagCollection = 0x74746366
+)
+
+type Index uint16
+
+type Face struct {
+	units      float64
+	longOffset bool
+	glyphs     int
+	metrics    int
+	ascent     int16
+	descent    int16
+	gap        int16
+
+	cmap []byte
+	hmtx []byte
+	loca []byte
+	glyf []byte
+
+	lookup func(rune) Index
+	kerns  map[uint32]int16
+}
+
+func Load(file string) (*Face, error) {
+	buf, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(buf)
+}
+
+func Parse(buf []byte) (*Face, error) {
+	if len(buf) < 12 {
+		return nil, ErrMalformed
+	}
+	switch tag := u32(buf, 0); tag {
+	case tagTrueType, tagTrue:
+	case tagCollection:
+		if len(buf) < 16 || u32(buf, 8) == 0 {
+			return nil, ErrMalformed
+		}
+		return parseFace(buf, int(u32(buf, 12)))
+	case tagOpenType:
+		return nil, fmt.Errorf("%w: CFF outlines", ErrUnsupported)
+	default:
+		return nil, ErrUnsupported
+	}
+	return parseFace(buf, 0)
+}
+
+func parseFace(buf []byte, offset int) (*Face, error) {
+	if offset+12 > len(buf) {
+		return nil, ErrMalformed
+	}
+	if u32(buf, offset) == tagOpenType {
+		return nil, fmt.Errorf("%w: CFF outlines", ErrUnsupported)
+	}
+	tables := make(map[string][]byte)
+	for i, n := 0, int(u16(buf, offset+4)); i < n; i++ {
+		rec := offset + 12 + i*16
+		if rec+16 > len(buf) {
+			return nil, ErrMalformed
+		}
+		var (
+			tag = string(buf[rec : rec+4])
+			off = int(u32(buf, rec+8))
+			siz = int(u32(buf, rec+12))
+		)
+		if off+siz > len(buf) {
+			return nil, ErrMalformed
+		}
+		tables[tag] = buf[off : off+siz]
+	}
+	for _, t := range []string{"head", "maxp", "hhea", "hmtx", "cmap", "loca", "glyf"} {
+		if _, ok := tables[t]; !ok {
+			if t == "glyf" {
+				if _, ok := tables["CFF "]; ok {
+					return nil, fmt.Errorf("%w: CFF outlines", ErrUnsupported)
+				}
+			}
+			return nil, fmt.Errorf("%w: %s", ErrMissing, t)
+		}
+	}
+	var (
+		f    Face
+		head = tables["head"]
+		hhea = tables["hhea"]
+		maxp = tables["maxp"]
+	)
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, ErrMalformed
+	}
+	f.units = float64(u16(head, 18))
+	f.longOffset = u16(head, 50) != 0
+	f.glyphs = int(u16(maxp, 4))
+	f.ascent = int16(u16(hhea, 4))
+	f.descent = int16(u16(hhea, 6))
+	f.gap = int16(u16(hhea, 8))
+	f.metrics = int(u16(hhea, 34))
+	f.hmtx = tables["hmtx"]
+	f.loca = tables["loca"]
+	f.glyf = tables["glyf"]
+	f.cmap = tables["cmap"]
+	if f.units == 0 || f.metrics == 0 || len(f.hmtx) < f.metrics*4 {
+		return nil, ErrMalformed
+	}
+	if err := f.parseCmap(); err != nil {
+		return nil, err
+	}
+	f.parseKern(tables["kern"])
+	return &f, nil
+}
+
+func (f *Face) UnitsPerEm() float64 {
+	return f.units
+}
+
+func (f *Face) Ascent(size float64) float64 {
+	return float64(f.ascent) * size / f.units
+}
+
+func (f *Face) Descent(size float64) float64 {
+	return float64(f.descent) * size / f.units
+}
+
+func (f *Face) LineGap(size float64) float64 {
+	return float64(f.gap) * size / f.units
+}
+
+func (f *Face) Index(r rune) Index {
+	return f.lookup(r)
+}
+
+func (f *Face) Advance(i Index) float64 {
+	n := int(i)
+	if n >= f.metrics {
+		n = f.metrics - 1
+	}
+	return float64(u16(f.hmtx, n*4))
+}
+
+func (f *Face) Kern(left, right Index) float64 {
+	if len(f.kerns) == 0 {
+		return 0
+	}
+	return float64(f.kerns[uint32(left)<<16|uint32(right)])
+}
+
+func (f *Face) Measure(str string, size float64) float64 {
+	var (
+		width float64
+		prev  Index
+	)
+	for i, r := range str {
+		g := f.Index(r)
+		if i > 0 {
+			width += f.Kern(prev, g)
+		}
+		width += f.Advance(g)
+		prev = g
+	}
+	return width * size / f.units
+}
+
+func (f *Face) Count() int {
+	return f.glyphs
+}
+
+func (f *Face) parseCmap() error {
+	if len(f.cmap) < 4 {
+		return ErrMalformed
+	}
+	var (
+		best  int
+		score int
+	)
+	for i, n := 0, int(u16(f.cmap, 2)); i < n; i++ {
+		rec := 4 + i*8
+		if rec+8 > len(f.cmap) {
+			return ErrMalformed
+		}
+		var (
+			platform = u16(f.cmap, rec)
+			encoding = u16(f.cmap, rec+2)
+			offset   = int(u32(f.cmap, rec+4))
+			value    int
+		)
+		if offset+2 > len(f.cmap) {
+			continue
+		}
+		switch format := u16(f.cmap, offset); {
+		case format == 12 && (platform == 0 || platform == 3 && encoding == 10):
+			value = 3
+		case format == 4 && (platform == 0 || platform == 3 && encoding == 1):
+			value = 2
+		case format == 4 && platform == 3 && encoding == 0:
+			value = 1
+		}
+		if value > score {
+			best, score = offset, value
+		}
+	}
+	if score == 0 {
+		return fmt.Errorf("%w: no unicode cmap", ErrUnsupported)
+	}
+	if u16(f.cmap, best) == 12 {
+		return f.parseCmap12(f.cmap[best:])
+	}
+	return f.parseCmap4(f.cmap[best:])
+}
+
+func (f *Face) parseCmap4(buf []byte) error {
+	if len(buf) < 14 {
+		return ErrMalformed
+	}
+	segs := int(u16(buf, 6) / 2)
+	if len(buf) < 16+segs*8 {
+		return ErrMalformed
+	}
+	var (
+		ends   = 14
+		starts = ends + segs*2 + 2
+		deltas = starts + segs*2
+		ranges = deltas + segs*2
+	)
+	f.lookup = func(r rune) Index {
+		if r > 0xFFFF || r < 0 {
+			return 0
+		}
+		c := uint16(r)
+		for i := 0; i < segs; i++ {
+			if u16(buf, ends+i*2) < c {
+				continue
+			}
+			start := u16(buf, starts+i*2)
+			if start > c {
+				return 0
+			}
+			var (
+				delta = u16(buf, deltas+i*2)
+				off   = int(u16(buf, ranges+i*2))
+			)
+			if off == 0 {
+				return Index(c + delta)
+			}
+			pos := ranges + i*2 + off + int(c-start)*2
+			if pos+2 > len(buf) {
+				return 0
+			}
+			g := u16(buf, pos)
+			if g == 0 {
+				return 0
+			}
+			return Index(g + delta)
+		}
+		return 0
+	}
+	return nil
+}
+
+func (f *Face) parseCmap12(buf []byte) error {
+	if len(buf) < 16 {
+		return ErrMalformed
+	}
+	groups := int(u32(buf, 12))
+	if len(buf) < 16+groups*12 {
+		return ErrMalformed
+	}
+	f.lookup = func(r rune) Index {
+		c := uint32(r)
+		lo, hi := 0, groups
+		for lo < hi {
+			var (
+				mid = (lo + hi) / 2
+				pos = 16 + mid*12
+			)
+			switch {
+			case c < u32(buf, pos):
+				hi = mid
+			case c > u32(buf, pos+4):
+				lo = mid + 1
+			default:
+				return Index(u32(buf, pos+8) + c - u32(buf, pos))
+			}
+		}
+		return 0
+	}
+	return nil
+}
+
+func (f *Face) parseKern(buf []byte) {
+	if len(buf) < 4 || u16(buf, 0) != 0 {
+		return
+	}
+	f.kerns = make(map[uint32]int16)
+	offset := 4
+	for i, n := 0, int(u16(buf, 2)); i < n && offset+6 <= len(buf); i++ {
+		var (
+			length   = int(u16(buf, offset+2))
+			coverage = u16(buf, offset+4)
+		)
+		if coverage>>8 == 0 && coverage&0x1 != 0 && offset+14 <= len(buf) {
+			pairs := int(u16(buf, offset+6))
+			for j := 0; j < pairs; j++ {
+				pos := offset + 14 + j*6
+				if pos+6 > len(buf) {
+					break
+				}
+				f.kerns[u32(buf, pos)] += int16(u16(buf, pos+4))
+			}
+		}
+		if length == 0 {
+			break
+		}
+		offset += length
+	}
+}
+
+func u16(buf []byte, offset int) uint16 {
+	return binary.BigEndian.Uint16(buf[offset:])
+}
+
+func u32(buf []byte, offset int) uint32 {
+	return binary.BigEndian.Uint32(buf[offset:])
+}
@@ -0,0 +1,426 @@
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/tiny.ttf")
+
+const tinyFont = "testdata/tiny.ttf"
+
+func TestTestdata(t *testing.T) {
+	buf := buildTiny()
+	if *update {
+		if err := ioutil.WriteFile(tinyFont, buf, 0644); err != nil {
+			t.Fatalf("fail to write %s: %s", tinyFont, err)
+		}
+	}
+	want, err := ioutil.ReadFile(tinyFont)
+	if err != nil {
+		t.Fatalf("fail to read %s: %s", tinyFont, err)
+	}
+	if !bytes.Equal(buf, want) {
+		t.Errorf("%s is out of date: run go test -update", tinyFont)
+	}
+}
+
+func TestParse(t *testing.T) {
+	f := loadTiny(t)
+	if f.UnitsPerEm() != 1000 {
+		t.Errorf("units per em: want 1000, got %f", f.UnitsPerEm())
+	}
+	if f.Count() != 6 {
+		t.Errorf("glyphs: want 6, got %d", f.Count())
+	}
+	if got := f.Ascent(10); got != 8 {
+		t.Errorf("ascent: want 8, got %f", got)
+	}
+	if got := f.Descent(10); got != -2 {
+		t.Errorf("descent: want -2, got %f", got)
+	}
+	if got := f.LineGap(10); got != 0.5 {
+		t.Errorf("line gap: want 0.5, got %f", got)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tiny := buildTiny()
+	missing := append([]byte{}, tiny...)
+	copy(missing[12:16], "xxxx")
+
+	data := []struct {
+		Name  string
+		Input []byte
+		Err   error
+	}{
+		{Name: "short", Input: []byte{0, 1, 0, 0}, Err: ErrMalformed},
+		{Name: "cff", Input: []byte("OTTO\x00\x00\x00\x00\x00\x00\x00\x00"), Err: ErrUnsupported},
+		{Name: "unknown", Input: []byte("wOFF\x00\x00\x00\x00\x00\x00\x00\x00"), Err: ErrUnsupported},
+		{Name: "truncated", Input: tiny[:len(tiny)/2], Err: ErrMalformed},
+		{Name: "missing", Input: missing, Err: ErrMissing},
+	}
+	for _, d := range data {
+		if _, err := Parse(d.Input); !errors.Is(err, d.Err) {
+			t.Errorf("%s: want %v, got %v", d.Name, d.Err, err)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	f := loadTiny(t)
+	for r, want := range map[rune]Index{'A': 1, 'V': 2, 'O': 3, ' ': 4, 'W': 5, 'Z': 0, 'a': 0, 0x1F600: 0} {
+		if got := f.Index(r); got != want {
+			t.Errorf("%q: want glyph %d, got %d", r, want, got)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	f := loadTiny(t)
+	for i, want := range []float64{500, 600, 500, 600, 250, 900} {
+		if got := f.Advance(Index(i)); got != want {
+			t.Errorf("glyph %d: want advance %f, got %f", i, want, got)
+		}
+	}
+	kerns := []struct {
+		Left  rune
+		Right rune
+		Want  float64
+	}{
+		{Left: 'A', Right: 'V', Want: -100},
+		{Left: 'V', Right: 'A', Want: -50},
+		{Left: 'A', Right: 'A', Want: 0},
+		{Left: 'V', Right: 'O', Want: 0},
+	}
+	for _, k := range kerns {
+		if got := f.Kern(f.Index(k.Left), f.Index(k.Right)); got != k.Want {
+			t.Errorf("%c%c: want kern %f, got %f", k.Left, k.Right, k.Want, got)
+		}
+	}
+	widths := []struct {
+		Input string
+		Want  float64
+	}{
+		{Input: "A", Want: 6},
+		{Input: "AV", Want: 10},
+		{Input: "AVA", Want: 15.5},
+		{Input: "A A", Want: 14.5},
+		{Input: "W", Want: 9},
+	}
+	for _, w := range widths {
+		if got := f.Measure(w.Input, 10); got != w.Want {
+			t.Errorf("%q: want width %f, got %f", w.Input, w.Want, got)
+		}
+	}
+}
+
+func TestGlyph(t *testing.T) {
+	var (
+		f    = loadTiny(t)
+		move = func(x, y float64) Segment { return Segment{Op: MoveTo, Args: [2]Point{{X: x, Y: y}}} }
+		line = func(x, y float64) Segment { return Segment{Op: LineTo, Args: [2]Point{{X: x, Y: y}}} }
+		quad = func(cx, cy, x, y float64) Segment {
+			return Segment{Op: QuadTo, Args: [2]Point{{X: cx, Y: cy}, {X: x, Y: y}}}
+		}
+		end = Segment{Op: Close}
+		vee = func(dx float64) []Segment {
+			return []Segment{move(dx, 700), line(dx+250, 0), line(dx+500, 700), line(dx, 700), end}
+		}
+	)
+	data := []struct {
+		Char rune
+		Want []Segment
+	}{
+		{
+			Char: 'A',
+			Want: []Segment{move(100, 0), line(500, 0), line(500, 700), line(100, 700), line(100, 0), end},
+		},
+		{
+			Char: 'V',
+			Want: vee(0),
+		},
+		{
+			Char: 'O',
+			Want: []Segment{move(250, 0), quad(500, 0, 500, 350), quad(500, 700, 250, 700), quad(0, 700, 0, 350), quad(0, 0, 250, 0), end},
+		},
+		{
+			Char: ' ',
+		},
+		{
+			Char: 'W',
+			Want: append(vee(0), vee(400)...),
+		},
+	}
+	for _, d := range data {
+		got, err := f.Glyph(f.Index(d.Char))
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", d.Char, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%q: segments mismatched: want %v, got %v", d.Char, d.Want, got)
+		}
+	}
+	if _, err := f.Glyph(Index(f.Count())); !errors.Is(err, ErrMalformed) {
+		t.Errorf("glyph out of range: want %v, got %v", ErrMalformed, err)
+	}
+}
+
+func loadTiny(t *testing.T) *Face {
+	t.Helper()
+	f, err := Load(tinyFont)
+	if err != nil {
+		t.Fatalf("fail to load %s: %s", tinyFont, err)
+	}
+	return f
+}
+
+type testPoint struct {
+	X, Y int16
+	On   bool
+}
+
+type testGlyph struct {
+	Char     rune
+	Advance  uint16
+	Contours [][]testPoint
+	Parts    [][3]int16
+}
+
+var tinyGlyphs = []testGlyph{
+	{Advance: 500},
+	{
+		Char:     'A',
+		Advance:  600,
+		Contours: [][]testPoint{{{100, 0, true}, {500, 0, true}, {500, 700, true}, {100, 700, true}}},
+	},
+	{
+		Char:     'V',
+		Advance:  500,
+		Contours: [][]testPoint{{{0, 700, true}, {250, 0, true}, {500, 700, true}}},
+	},
+	{
+		Char:     'O',
+		Advance:  600,
+		Contours: [][]testPoint{{{0, 0, false}, {500, 0, false}, {500, 700, false}, {0, 700, false}}},
+	},
+	{Char: ' ', Advance: 250},
+	{
+		Char:    'W',
+		Advance: 900,
+		Parts:   [][3]int16{{2, 0, 0}, {2, 400, 0}},
+	},
+}
+
+var tinyKerns = [][3]int16{
+	{1, 2, -100},
+	{2, 1, -50},
+}
+
+func buildTiny() []byte {
+	var (
+		glyf  []byte
+		loca  []byte
+		hmtx  []byte
+		cmap  []byte
+		kern  []byte
+		chars []testGlyph
+	)
+	for i, g := range tinyGlyphs {
+		loca = put16(loca, uint16(len(glyf)/2))
+		glyf = append(glyf, encodeGlyph(g)...)
+		if len(glyf)%2 != 0 {
+			glyf = append(glyf, 0)
+		}
+		hmtx = put16(hmtx, g.Advance)
+		hmtx = put16(hmtx, 0)
+		if i > 0 {
+			chars = append(chars, g)
+		}
+	}
+	loca = put16(loca, uint16(len(glyf)/2))
+
+	sort.Slice(chars, func(i, j int) bool { return chars[i].Char < chars[j].Char })
+	var (
+		segs   = len(chars) + 1
+		ends   []byte
+		starts []byte
+		deltas []byte
+	)
+	for _, g := range chars {
+		var index int
+		for i := range tinyGlyphs {
+			if tinyGlyphs[i].Char == g.Char {
+				index = i
+			}
+		}
+		ends = put16(ends, uint16(g.Char))
+		starts = put16(starts, uint16(g.Char))
+		deltas = put16(deltas, uint16(index-int(g.Char)))
+	}
+	ends = put16(ends, 0xFFFF)
+	starts = put16(starts, 0xFFFF)
+	deltas = put16(deltas, 1)
+
+	cmap = put16(cmap, 0)
+	cmap = put16(cmap, 1)
+	cmap = put16(cmap, 3)
+	cmap = put16(cmap, 1)
+	cmap = put32(cmap, 12)
+	cmap = put16(cmap, 4)
+	cmap = put16(cmap, uint16(16+segs*8))
+	cmap = put16(cmap, 0)
+	cmap = put16(cmap, uint16(segs*2))
+	cmap = append(cmap, make([]byte, 6)...)
+	cmap = append(cmap, ends...)
+	cmap = put16(cmap, 0)
+	cmap = append(cmap, starts...)
+	cmap = append(cmap, deltas...)
+	cmap = append(cmap, make([]byte, segs*2)...)
+
+	kern = put16(kern, 0)
+	kern = put16(kern, 1)
+	kern = put16(kern, 0)
+	kern = put16(kern, uint16(14+len(tinyKerns)*6))
+	kern = put16(kern, 1)
+	kern = put16(kern, uint16(len(tinyKerns)))
+	kern = append(kern, make([]byte, 6)...)
+	for _, k := range tinyKerns {
+		kern = put16(kern, uint16(k[0]))
+		kern = put16(kern, uint16(k[1]))
+		kern = put16(kern, uint16(k[2]))
+	}
+
+	head := make([]byte, 54)
+	binary.BigEndian.PutUint32(head[0:], 0x00010000)
+	binary.BigEndian.PutUint32(head[12:], 0x5F0F3CF5)
+	binary.BigEndian.PutUint16(head[18:], 1000)
+
+	hhea := make([]byte, 36)
+	binary.BigEndian.PutUint32(hhea[0:], 0x00010000)
+	binary.BigEndian.PutUint16(hhea[4:], 800)
+	binary.BigEndian.PutUint16(hhea[6:], uint16(0xFFFF-199))
+	binary.BigEndian.PutUint16(hhea[8:], 50)
+	binary.BigEndian.PutUint16(hhea[34:], uint16(len(tinyGlyphs)))
+
+	maxp := make([]byte, 6)
+	binary.BigEndian.PutUint32(maxp[0:], 0x00005000)
+	binary.BigEndian.PutUint16(maxp[4:], uint16(len(tinyGlyphs)))
+
+	tables := []struct {
+		Tag  string
+		Data []byte
+	}{
+		{Tag: "cmap", Data: cmap},
+		{Tag: "glyf", Data: glyf},
+		{Tag: "head", Data: head},
+		{Tag: "hhea", Data: hhea},
+		{Tag: "hmtx", Data: hmtx},
+		{Tag: "kern", Data: kern},
+		{Tag: "loca", Data: loca},
+		{Tag: "maxp", Data: maxp},
+	}
+	var (
+		buf    []byte
+		offset = 12 + len(tables)*16
+		body   []byte
+	)
+	buf = put32(buf, tagTrueType)
+	buf = put16(buf, uint16(len(tables)))
+	buf = append(buf, make([]byte, 6)...)
+	for _, t := range tables {
+		buf = append(buf, t.Tag...)
+		buf = put32(buf, 0)
+		buf = put32(buf, uint32(offset+len(body)))
+		buf = put32(buf, uint32(len(t.Data)))
+		body = append(body, t.Data...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+	return append(buf, body...)
+}
+
+func encodeGlyph(g testGlyph) []byte {
+	var buf []byte
+	if len(g.Parts) > 0 {
+		buf = put16(buf, 0xFFFF)
+		buf = append(buf, make([]byte, 8)...)
+		for i, p := range g.Parts {
+			flags := uint16(flagWordArgs | flagXYArgs)
+			if i < len(g.Parts)-1 {
+				flags |= flagMore
+			}
+			buf = put16(buf, flags)
+			buf = put16(buf, uint16(p[0]))
+			buf = put16(buf, uint16(p[1]))
+			buf = put16(buf, uint16(p[2]))
+		}
+		return buf
+	}
+	if len(g.Contours) == 0 {
+		return nil
+	}
+	var (
+		points []testPoint
+		ends   []byte
+	)
+	for _, c := range g.Contours {
+		points = append(points, c...)
+		ends = put16(ends, uint16(len(points)-1))
+	}
+	buf = put16(buf, uint16(len(g.Contours)))
+	buf = append(buf, make([]byte, 8)...)
+	buf = append(buf, ends...)
+	buf = put16(buf, 0)
+
+	var (
+		flags  = make([]byte, len(points))
+		xs, ys []byte
+		px, py int16
+	)
+	for i, p := range points {
+		if p.On {
+			flags[i] |= flagOnCurve
+		}
+		xs = appendCoord(xs, &flags[i], p.X-px, flagShortX, flagSameX)
+		ys = appendCoord(ys, &flags[i], p.Y-py, flagShortY, flagSameY)
+		px, py = p.X, p.Y
+	}
+	buf = append(buf, flags...)
+	buf = append(buf, xs...)
+	return append(buf, ys...)
+}
+
+func appendCoord(buf []byte, flag *byte, delta int16, short, same byte) []byte {
+	switch {
+	case delta == 0:
+		*flag |= same
+		return buf
+	case delta > -256 && delta < 256:
+		*flag |= short
+		if delta > 0 {
+			*flag |= same
+		} else {
+			delta = -delta
+		}
+		return append(buf, byte(delta))
+	default:
+		return put16(buf, uint16(delta))
+	}
+}
+
+func put16(buf []byte, v uint16) []byte {
+	return append(buf, byte(v>>8), byte(v))
+}
+
+func put32(buf []byte, v uint32) []byte {
+	return append(buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
@@ -0,0 +1,327 @@
+package font
+
+import (
+	"fmt"
+)
+
+const maxDepth = 8
+
+type Op int
+
+const (
+	MoveTo Op = iota
+	LineTo
+	QuadTo
+	Close
+)
+
+func (o Op) args() int {
+	switch o {
+	case MoveTo, LineTo:
+		return 1
+	case QuadTo:
+		return 2
+	default:
+		return 0
+	}
+}
+
+type Point struct {
+	X float64
+	Y float64
+}
+
+type Segment struct {
+	Op   Op
+	Args [2]Point
+}
+
+func (f *Face) Glyph(i Index) ([]Segment, error) {
+	return f.glyph(i, 0)
+}
+
+func (f *Face) glyph(i Index, depth int) ([]Segment, error) {
+	if int(i) >= f.glyphs {
+		return nil, fmt.Errorf("%w: glyph %d out of range", ErrMalformed, i)
+	}
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: composite glyph too deep", ErrMalformed)
+	}
+	buf, err := f.data(i)
+	if err != nil || len(buf) == 0 {
+		return nil, err
+	}
+	if len(buf) < 10 {
+		return nil, ErrMalformed
+	}
+	n := int16(u16(buf, 0))
+	if n < 0 {
+		return f.composite(buf[10:], depth)
+	}
+	return simple(buf[10:], int(n))
+}
+
+func (f *Face) data(i Index) ([]byte, error) {
+	var start, end int
+	if f.longOffset {
+		if int(i)*4+8 > len(f.loca) {
+			return nil, ErrMalformed
+		}
+		start = int(u32(f.loca, int(i)*4))
+		end = int(u32(f.loca, int(i)*4+4))
+	} else {
+		if int(i)*2+4 > len(f.loca) {
+			return nil, ErrMalformed
+		}
+		start = int(u16(f.loca, int(i)*2)) * 2
+		end = int(u16(f.loca, int(i)*2+2)) * 2
+	}
+	if start > end || end > len(f.glyf) {
+		return nil, ErrMalformed
+	}
+	return f.glyf[start:end], nil
+}
+
+const (
+	flagOnCurve  = 0x01
+	flagShortX   = 0x02
+	flagShortY   = 0x04
+	flagRepeat   = 0x08
+	flagSameX    = 0x10
+	flagSameY    = 0x20
+	flagWordArgs = 0x0001
+	flagXYArgs   = 0x0002
+	flagScale    = 0x0008
+	flagMore     = 0x0020
+	flagScaleXY  = 0x0040
+	flagMatrix   = 0x0080
+)
+
+type point struct {
+	Point
+	on bool
+}
+
+func simple(buf []byte, contours int) ([]Segment, error) {
+	if len(buf) < contours*2+2 {
+		return nil, ErrMalformed
+	}
+	var (
+		ends   = make([]int, contours)
+		offset = contours*2 + 2
+		count  int
+	)
+	for i := range ends {
+		ends[i] = int(u16(buf, i*2))
+	}
+	if contours > 0 {
+		count = ends[contours-1] + 1
+	}
+	offset += int(u16(buf, contours*2))
+	flags := make([]byte, 0, count)
+	for len(flags) < count {
+		if offset >= len(buf) {
+			return nil, ErrMalformed
+		}
+		b := buf[offset]
+		offset++
+		flags = append(flags, b)
+		if b&flagRepeat == 0 {
+			continue
+		}
+		if offset >= len(buf) {
+			return nil, ErrMalformed
+		}
+		for n := buf[offset]; n > 0 && len(flags) < count; n-- {
+			flags = append(flags, b)
+		}
+		offset++
+	}
+	points := make([]point, count)
+	for _, axis := range []struct {
+		short byte
+		same  byte
+		set   func(*point, float64)
+	}{
+		{short: flagShortX, same: flagSameX, set: func(p *point, v float64) { p.X = v }},
+		{short: flagShortY, same: flagSameY, set: func(p *point, v float64) { p.Y = v }},
+	} {
+		var v int
+		for i, b := range flags {
+			switch {
+			case b&axis.short != 0:
+				if offset >= len(buf) {
+					return nil, ErrMalformed
+				}
+				d := int(buf[offset])
+				offset++
+				if b&axis.same == 0 {
+					d = -d
+				}
+				v += d
+			case b&axis.same == 0:
+				if offset+2 > len(buf) {
+					return nil, ErrMalformed
+				}
+				v += int(int16(u16(buf, offset)))
+				offset += 2
+			}
+			axis.set(&points[i], float64(v))
+			points[i].on = b&flagOnCurve != 0
+		}
+	}
+	var (
+		segs  []Segment
+		start int
+	)
+	for _, end := range ends {
+		if end < start || end >= count {
+			return nil, ErrMalformed
+		}
+		segs = append(segs, contour(points[start:end+1])...)
+		start = end + 1
+	}
+	return segs, nil
+}
+
+func contour(points []point) []Segment {
+	if len(points) == 0 {
+		return nil
+	}
+	var (
+		segs  []Segment
+		first = -1
+		start Point
+	)
+	for i := range points {
+		if points[i].on {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		start = midpoint(points[0].Point, points[1%len(points)].Point)
+		first = 1
+	} else {
+		start = points[first].Point
+		first++
+	}
+	segs = append(segs, Segment{Op: MoveTo, Args: [2]Point{start}})
+
+	var (
+		ctrl    Point
+		pending bool
+	)
+	for i := 0; i < len(points); i++ {
+		p := points[(first+i)%len(points)]
+		if p.on {
+			if pending {
+				segs = append(segs, Segment{Op: QuadTo, Args: [2]Point{ctrl, p.Point}})
+			} else {
+				segs = append(segs, Segment{Op: LineTo, Args: [2]Point{p.Point}})
+			}
+			pending = false
+			continue
+		}
+		if pending {
+			mid := midpoint(ctrl, p.Point)
+			segs = append(segs, Segment{Op: QuadTo, Args: [2]Point{ctrl, mid}})
+		}
+		ctrl, pending = p.Point, true
+	}
+	if pending {
+		segs = append(segs, Segment{Op: QuadTo, Args: [2]Point{ctrl, start}})
+	}
+	return append(segs, Segment{Op: Close})
+}
+
+func (f *Face) composite(buf []byte, depth int) ([]Segment, error) {
+	var (
+		segs   []Segment
+		offset int
+	)
+	for {
+		if offset+4 > len(buf) {
+			return nil, ErrMalformed
+		}
+		var (
+			flags = u16(buf, offset)
+			index = Index(u16(buf, offset+2))
+			dx    float64
+			dy    float64
+			mat   = [4]float64{1, 0, 0, 1}
+		)
+		offset += 4
+		if flags&flagWordArgs != 0 {
+			if offset+4 > len(buf) {
+				return nil, ErrMalformed
+			}
+			dx = float64(int16(u16(buf, offset)))
+			dy = float64(int16(u16(buf, offset+2)))
+			offset += 4
+		} else {
+			if offset+2 > len(buf) {
+				return nil, ErrMalformed
+			}
+			dx = float64(int8(buf[offset]))
+			dy = float64(int8(buf[offset+1]))
+			offset += 2
+		}
+		if flags&flagXYArgs == 0 {
+			dx, dy = 0, 0
+		}
+		switch {
+		case flags&flagScale != 0:
+			if offset+2 > len(buf) {
+				return nil, ErrMalformed
+			}
+			mat[0] = f2dot14(buf, offset)
+			mat[3] = mat[0]
+			offset += 2
+		case flags&flagScaleXY != 0:
+			if offset+4 > len(buf) {
+				return nil, ErrMalformed
+			}
+			mat[0] = f2dot14(buf, offset)
+			mat[3] = f2dot14(buf, offset+2)
+			offset += 4
+		case flags&flagMatrix != 0:
+			if offset+8 > len(buf) {
+				return nil, ErrMalformed
+			}
+			for i := range mat {
+				mat[i] = f2dot14(buf, offset+i*2)
+			}
+			offset += 8
+		}
+		list, err := f.glyph(index, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range list {
+			for i := 0; i < s.Op.args(); i++ {
+				p := s.Args[i]
+				s.Args[i] = Point{
+					X: p.X*mat[0] + p.Y*mat[2] + dx,
+					Y: p.X*mat[1] + p.Y*mat[3] + dy,
+				}
+			}
+			segs = append(segs, s)
+		}
+		if flags&flagMore == 0 {
+			break
+		}
+	}
+	return segs, nil
+}
+
+func midpoint(a, b Point) Point {
+	return Point{
+		X: (a.X + b.X) / 2,
+		Y: (a.Y + b.Y) / 2,
+	}
+}
+
+func f2dot14(buf []byte, offset int) float64 {
+	return float64(int16(u16(buf, offset))) / (1 << 14)
+}
@@ -0,0 +1,298 @@
+package geo
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/chart"
+	"github.com/midbel/svg/scale"
+)
+
+const (
+	defaultMaxRadius = 20
+	defaultOpacity   = 0.7
+	rampSamples      = 9
+	legendSymbols    = 3
+	missingFill      = "#ebedf0"
+)
+
+type Choropleth struct {
+	Map
+	Values     map[string]float64
+	Key        string
+	Scale      scale.ColorScale
+	Title      string
+	Format     func(float64) string
+	Legend     chart.Placement
+	OmitLegend bool
+	Font       svg.Font
+}
+
+func (c Choropleth) Render(w io.Writer) error {
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := c.Element()
+	el.Render(ws)
+	return nil
+}
+
+func (c Choropleth) Element() svg.Element {
+	var (
+		canvas = c.canvas()
+		colors = c.colors()
+		leg    = c.legend(colors)
+		size   svg.Dim
+	)
+	if leg != nil {
+		size = leg.Size()
+	}
+	pos, dim := c.region(size, c.Legend)
+	proj := c.fit(pos, dim)
+	canvas.Append(c.features(proj, func(f Feature, path *svg.Path) {
+		key := lookup(f, c.Key)
+		v, ok := c.Values[key]
+		if !ok || math.IsNaN(v) {
+			path.Class = append(path.Class, "missing")
+			path.Fill = svg.NewFill(missingFill)
+			return
+		}
+		path.Fill = svg.NewFill(colors.Color(v))
+		path.Data = appendDatum(path.Data, svg.Datum{Name: "value", Value: v})
+		if c.Key != "" {
+			path.Data = appendDatum(path.Data, svg.Datum{Name: c.Key, Value: key})
+		}
+	}))
+	if leg != nil {
+		leg.Place(pos, dim, c.Legend)
+		canvas.Append(leg.Element())
+	}
+	return canvas.AsElement()
+}
+
+func (c Choropleth) legend(colors scale.ColorScale) *chart.Legend {
+	if c.OmitLegend {
+		return nil
+	}
+	leg := chart.Legend{
+		Title:  c.Title,
+		Font:   c.Font,
+		Margin: c.margin(),
+		Ramp: &chart.Ramp{
+			Domain: colors.Extent(),
+			Colors: scale.Sample(colors, rampSamples),
+			Format: c.Format,
+		},
+	}
+	switch c.Legend {
+	case chart.PlaceTop, chart.PlaceBottom:
+		leg.Horizontal = true
+	}
+	return &leg
+}
+
+func (c Choropleth) colors() scale.ColorScale {
+	if c.Scale != nil {
+		return c.Scale
+	}
+	min, max := extent(c.Values)
+	return scale.NewSequential(scale.NewRange(min, max), scale.Blues)
+}
+
+type SymbolMap struct {
+	Map
+	Values     map[string]float64
+	Key        string
+	MaxRadius  float64
+	Color      string
+	Title      string
+	Format     func(float64) string
+	Legend     chart.Placement
+	OmitLegend bool
+	Font       svg.Font
+}
+
+func (s SymbolMap) Render(w io.Writer) error {
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := s.Element()
+	el.Render(ws)
+	return nil
+}
+
+func (s SymbolMap) Element() svg.Element {
+	var (
+		canvas  = s.canvas()
+		_, max  = extent(s.Values)
+		radius  = scale.NewSqrt(scale.NewRange(0, max), scale.NewRange(0, s.maxRadius()))
+		size    svg.Dim
+		symbols svg.Group
+	)
+	if !s.OmitLegend {
+		_, size = s.legend(radius, max)
+	}
+	pos, dim := s.region(size, s.Legend)
+	proj := s.fit(pos, dim)
+	canvas.Append(s.features(proj, nil))
+
+	type symbol struct {
+		Feature
+		Key   string
+		Value float64
+		Pos   svg.Pos
+	}
+	var list []symbol
+	for _, f := range s.Features {
+		key := lookup(f, s.Key)
+		v, ok := s.Values[key]
+		if !ok || math.IsNaN(v) || v <= 0 {
+			continue
+		}
+		pos, ok := proj.Centroid(f.Geometry)
+		if !ok {
+			continue
+		}
+		list = append(list, symbol{Feature: f, Key: key, Value: v, Pos: pos})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Value > list[j].Value
+	})
+	symbols.Class = append(symbols.Class, "symbols")
+	for _, sym := range list {
+		var circle svg.Circle
+		circle.Pos = sym.Pos
+		circle.Radius = radius.Scale(sym.Value)
+		circle.Fill = svg.NewFill(s.color())
+		circle.Fill.Opacity = defaultOpacity
+		circle.Stroke = svg.NewStroke("#ffffff", 0.5)
+		circle.Class = append(circle.Class, "symbol")
+		circle.Data = appendDatum(s.data(sym.Feature), svg.Datum{Name: "value", Value: sym.Value})
+		if s.Key != "" {
+			circle.Data = appendDatum(circle.Data, svg.Datum{Name: s.Key, Value: sym.Key})
+		}
+		symbols.Append(circle.AsElement())
+	}
+	canvas.Append(symbols.AsElement())
+	if !s.OmitLegend {
+		grp, size := s.legend(radius, max)
+		pos = s.Legend.Position(pos, dim, size, s.margin())
+		grp.Transform = svg.Translate(pos.X, pos.Y)
+		canvas.Append(grp.AsElement())
+	}
+	return canvas.AsElement()
+}
+
+func (s SymbolMap) legend(radius scale.Pow, max float64) (svg.Group, svg.Dim) {
+	var (
+		grp    svg.Group
+		font   = s.Font.OrDefault()
+		values = scale.NewLinear(scale.NewRange(0, max), scale.NewRange(0, 1)).Nice(3).Values(3)
+		outer  = s.maxRadius()
+		top    float64
+		width  float64
+	)
+	grp.Class = append(grp.Class, "legend")
+	if s.Title != "" {
+		title := svg.NewText(s.Title)
+		title.Font = font
+		title.Font.Weight = "bold"
+		title.Baseline = "hanging"
+		title.Class = append(title.Class, "title")
+		grp.Append(title.AsElement())
+		top = font.Size * 1.5
+		width = svg.EstimateWidth(s.Title, font)
+	}
+	for i, n := len(values)-1, 0; i >= 0 && n < legendSymbols; i-- {
+		v := values[i]
+		if v <= 0 || v > max {
+			continue
+		}
+		n++
+		var (
+			r      = radius.Scale(v)
+			circle svg.Circle
+			line   svg.Line
+			label  = s.format(v)
+			text   = svg.NewText(label)
+			bottom = top + 2*outer
+		)
+		circle.Pos = svg.NewPos(outer, bottom-r)
+		circle.Radius = r
+		circle.Fill = svg.NewFill("none")
+		circle.Stroke = svg.NewStroke("#555555", 0.5)
+		line = svg.NewLine(svg.NewPos(outer, bottom-2*r), svg.NewPos(2*outer+defaultMargin/2, bottom-2*r))
+		line.Stroke = svg.NewStroke("#555555", 0.5)
+		line.Stroke.DashArray = []int{2, 2}
+		text.Font = font
+		text.Pos = svg.NewPos(2*outer+defaultMargin, bottom-2*r)
+		text.Baseline = "middle"
+		grp.Append(circle.AsElement())
+		grp.Append(line.AsElement())
+		grp.Append(text.AsElement())
+		width = math.Max(width, 2*outer+defaultMargin+svg.EstimateWidth(label, font))
+	}
+	return grp, svg.NewDim(width, top+2*outer)
+}
+
+func (s SymbolMap) format(v float64) string {
+	if s.Format != nil {
+		return s.Format(v)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (s SymbolMap) maxRadius() float64 {
+	if s.MaxRadius <= 0 {
+		return defaultMaxRadius
+	}
+	return s.MaxRadius
+}
+
+func (s SymbolMap) color() string {
+	if s.Color == "" {
+		return chart.Palette[0]
+	}
+	return s.Color
+}
+
+func appendDatum(list []svg.Datum, d svg.Datum) []svg.Datum {
+	for _, x := range list {
+		if x.Name == d.Name {
+			return list
+		}
+	}
+	return append(list, d)
+}
+
+func lookup(f Feature, key string) string {
+	if key == "" {
+		return f.Id
+	}
+	return f.Property(key)
+}
+
+func extent(values map[string]float64) (float64, float64) {
+	var (
+		min = math.Inf(1)
+		max = math.Inf(-1)
+	)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	if math.IsInf(min, 0) {
+		return 0, 1
+	}
+	if min == max {
+		return min - 1, max + 1
+	}
+	return min, max
+}
@@ -0,0 +1,285 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/midbel/svg"
+)
+
+const (
+	defaultPointRadius = 4.5
+	densifyStep        = 2.5
+)
+
+func (p Projection) stream(path *svg.Path, g Geometry) {
+	for _, pt := range g.Points {
+		pos, ok := p.Project(pt.Lon, pt.Lat)
+		if !ok {
+			continue
+		}
+		var (
+			r     = defaultPointRadius
+			left  = pos.Adjust(-r, 0)
+			right = pos.Adjust(r, 0)
+		)
+		path.AbsMoveTo(left)
+		path.AbsArcTo(right, r, r, 0, true, true)
+		path.AbsArcTo(left, r, r, 0, true, true)
+		path.ClosePath()
+	}
+	for _, line := range g.Lines {
+		for _, part := range p.clipLine(line) {
+			p.polyline(path, part, false)
+		}
+	}
+	for _, poly := range g.Polygons {
+		for _, ring := range poly {
+			for _, part := range p.clipRing(ring) {
+				p.polyline(path, part, true)
+			}
+		}
+	}
+	for _, sub := range g.Geometries {
+		p.stream(path, sub)
+	}
+}
+
+func (p Projection) polyline(path *svg.Path, pts []Point, closed bool) {
+	if len(pts) < 2 || closed && len(pts) < 3 {
+		return
+	}
+	for i, pt := range pts {
+		pos := p.forward(pt.Lon, pt.Lat)
+		if i == 0 {
+			path.AbsMoveTo(pos)
+		} else {
+			path.AbsLineTo(pos)
+		}
+	}
+	if closed {
+		path.ClosePath()
+	}
+}
+
+func (p Projection) clipLine(line []Point) [][]Point {
+	var (
+		pts   = p.unwrap(line)
+		h     = p.horizon()
+		parts [][]Point
+	)
+	for _, shift := range shifts(pts, h) {
+		lo, hi := shift-h, shift+h
+		var curr []Point
+		for i := 1; i < len(pts); i++ {
+			a, b, exit, skip := clipSegment(pts[i-1], pts[i], lo, hi)
+			if skip {
+				continue
+			}
+			a.Lon -= shift
+			b.Lon -= shift
+			if len(curr) == 0 {
+				curr = append(curr, a)
+			}
+			curr = append(curr, b)
+			if exit {
+				parts = append(parts, curr)
+				curr = nil
+			}
+		}
+		if len(curr) > 0 {
+			parts = append(parts, curr)
+		}
+	}
+	return parts
+}
+
+func (p Projection) clipRing(ring []Point) [][]Point {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return nil
+	}
+	var (
+		pts   = p.unwrap(append(ring, ring[0]))
+		first = pts[0]
+		last  = pts[len(pts)-1]
+		h     = p.horizon()
+		parts [][]Point
+	)
+	if math.Abs(last.Lon-first.Lon) > 180 {
+		pole := 90.0
+		if meanLat(pts) < 0 {
+			pole = -90
+		}
+		pts = append(pts, Point{Lon: last.Lon, Lat: pole}, Point{Lon: first.Lon, Lat: pole})
+	} else {
+		pts = pts[:len(pts)-1]
+	}
+	for _, shift := range shifts(pts, h) {
+		lo, hi := shift-h, shift+h
+		part := clipEdge(pts, func(pt Point) bool { return pt.Lon >= lo }, lo)
+		part = clipEdge(part, func(pt Point) bool { return pt.Lon <= hi }, hi)
+		if len(part) < 3 {
+			continue
+		}
+		part = densify(part, lo, hi)
+		for i := range part {
+			part[i].Lon -= shift
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+func (p Projection) unwrap(line []Point) []Point {
+	var (
+		pts  = make([]Point, 0, len(line))
+		prev float64
+	)
+	for i, pt := range line {
+		lon, lat := p.rotate(pt.Lon, pt.Lat)
+		if i > 0 {
+			delta := lon - prev
+			if delta > 180 {
+				delta -= 360
+			} else if delta < -180 {
+				delta += 360
+			}
+			prev = lon
+			lon = pts[i-1].Lon + delta
+		} else {
+			prev = lon
+		}
+		pts = append(pts, Point{Lon: lon, Lat: lat})
+	}
+	return pts
+}
+
+func shifts(pts []Point, h float64) []float64 {
+	if len(pts) == 0 {
+		return nil
+	}
+	var (
+		min = math.Inf(1)
+		max = math.Inf(-1)
+	)
+	for _, pt := range pts {
+		min = math.Min(min, pt.Lon)
+		max = math.Max(max, pt.Lon)
+	}
+	var (
+		list  []float64
+		start = math.Ceil((min - h) / 360)
+		end   = math.Floor((max + h) / 360)
+	)
+	for k := start; k <= end; k++ {
+		lo, hi := k*360-h, k*360+h
+		if max <= lo || min >= hi {
+			continue
+		}
+		list = append(list, k*360)
+	}
+	return list
+}
+
+func clipSegment(a, b Point, lo, hi float64) (Point, Point, bool, bool) {
+	d := b.Lon - a.Lon
+	if d == 0 {
+		return a, b, false, a.Lon < lo || a.Lon > hi
+	}
+	var (
+		t0 = (lo - a.Lon) / d
+		t1 = (hi - a.Lon) / d
+	)
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	t0 = math.Max(t0, 0)
+	t1 = math.Min(t1, 1)
+	if t0 > t1 {
+		return a, b, false, true
+	}
+	var (
+		from = a
+		to   = b
+	)
+	if t0 > 0 {
+		from = lerp(a, b, t0)
+	}
+	if t1 < 1 {
+		to = lerp(a, b, t1)
+	}
+	return from, to, t1 < 1, false
+}
+
+func clipEdge(pts []Point, inside func(Point) bool, lon float64) []Point {
+	var out []Point
+	for i := range pts {
+		var (
+			curr = pts[i]
+			prev = pts[(i+len(pts)-1)%len(pts)]
+		)
+		switch in, was := inside(curr), inside(prev); {
+		case in && !was:
+			out = push(out, crossing(prev, curr, lon))
+			out = push(out, curr)
+		case in:
+			out = push(out, curr)
+		case was:
+			out = push(out, crossing(prev, curr, lon))
+		}
+	}
+	if n := len(out); n > 1 && out[0] == out[n-1] {
+		out = out[:n-1]
+	}
+	return out
+}
+
+func push(pts []Point, pt Point) []Point {
+	if n := len(pts); n > 0 && pts[n-1] == pt {
+		return pts
+	}
+	return append(pts, pt)
+}
+
+func densify(pts []Point, lo, hi float64) []Point {
+	var out []Point
+	for i := range pts {
+		var (
+			curr = pts[i]
+			next = pts[(i+1)%len(pts)]
+		)
+		out = append(out, curr)
+		if curr.Lon != next.Lon || curr.Lon != lo && curr.Lon != hi {
+			continue
+		}
+		n := int(math.Abs(next.Lat-curr.Lat) / densifyStep)
+		for j := 1; j < n; j++ {
+			out = append(out, lerp(curr, next, float64(j)/float64(n)))
+		}
+	}
+	return out
+}
+
+func crossing(a, b Point, lon float64) Point {
+	t := (lon - a.Lon) / (b.Lon - a.Lon)
+	pt := lerp(a, b, t)
+	pt.Lon = lon
+	return pt
+}
+
+func lerp(a, b Point, t float64) Point {
+	return Point{
+		Lon: a.Lon + t*(b.Lon-a.Lon),
+		Lat: a.Lat + t*(b.Lat-a.Lat),
+	}
+}
+
+func meanLat(pts []Point) float64 {
+	var sum float64
+	for _, pt := range pts {
+		sum += pt.Lat
+	}
+	return sum / float64(len(pts))
+}
@@ -0,0 +1,239 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var (
+	ErrType     = errors.New("unsupported geojson type")
+	ErrPosition = errors.New("invalid position")
+)
+
+const (
+	TypePoint              = "Point"
+	TypeMultiPoint         = "MultiPoint"
+	TypeLineString         = "LineString"
+	TypeMultiLineString    = "MultiLineString"
+	TypePolygon            = "Polygon"
+	TypeMultiPolygon       = "MultiPolygon"
+	TypeGeometryCollection = "GeometryCollection"
+	TypeFeature            = "Feature"
+	TypeFeatureCollection  = "FeatureCollection"
+)
+
+type Point struct {
+	Lon float64
+	Lat float64
+}
+
+type Geometry struct {
+	Type       string
+	Points     []Point
+	Lines      [][]Point
+	Polygons   [][][]Point
+	Geometries []Geometry
+}
+
+func (g Geometry) lineal() bool {
+	if len(g.Points) > 0 || len(g.Polygons) > 0 {
+		return false
+	}
+	for _, sub := range g.Geometries {
+		if !sub.lineal() {
+			return false
+		}
+	}
+	return len(g.Lines) > 0 || len(g.Geometries) > 0
+}
+
+type Feature struct {
+	Id         string
+	Properties map[string]interface{}
+	Geometry   Geometry
+}
+
+func (f Feature) Property(name string) string {
+	switch v := f.Properties[name].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type object struct {
+	Type        string                 `json:"type"`
+	Id          interface{}            `json:"id"`
+	Properties  map[string]interface{} `json:"properties"`
+	Geometry    *object                `json:"geometry"`
+	Features    []object               `json:"features"`
+	Coordinates json.RawMessage        `json:"coordinates"`
+	Geometries  []object               `json:"geometries"`
+}
+
+func Parse(r io.Reader) ([]Feature, error) {
+	var obj object
+	if err := json.NewDecoder(r).Decode(&obj); err != nil {
+		return nil, err
+	}
+	switch obj.Type {
+	case TypeFeatureCollection:
+		list := make([]Feature, 0, len(obj.Features))
+		for _, o := range obj.Features {
+			f, err := feature(o)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, f)
+		}
+		return list, nil
+	case TypeFeature:
+		f, err := feature(obj)
+		if err != nil {
+			return nil, err
+		}
+		return []Feature{f}, nil
+	default:
+		g, err := geometry(obj)
+		if err != nil {
+			return nil, err
+		}
+		return []Feature{{Geometry: g}}, nil
+	}
+}
+
+func feature(obj object) (Feature, error) {
+	if obj.Type != TypeFeature {
+		return Feature{}, fmt.Errorf("%w: %s", ErrType, obj.Type)
+	}
+	f := Feature{
+		Properties: obj.Properties,
+	}
+	switch id := obj.Id.(type) {
+	case string:
+		f.Id = id
+	case float64:
+		f.Id = strconv.FormatFloat(id, 'f', -1, 64)
+	}
+	if obj.Geometry == nil {
+		return f, nil
+	}
+	g, err := geometry(*obj.Geometry)
+	if err != nil {
+		return f, err
+	}
+	f.Geometry = g
+	return f, nil
+}
+
+func geometry(obj object) (Geometry, error) {
+	var (
+		g   = Geometry{Type: obj.Type}
+		err error
+	)
+	switch obj.Type {
+	case TypePoint:
+		var pos []float64
+		if err = json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			break
+		}
+		var pt Point
+		if pt, err = position(pos); err == nil {
+			g.Points = []Point{pt}
+		}
+	case TypeMultiPoint:
+		var pos [][]float64
+		if err = json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			break
+		}
+		g.Points, err = positions(pos)
+	case TypeLineString:
+		var pos [][]float64
+		if err = json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			break
+		}
+		var line []Point
+		if line, err = positions(pos); err == nil {
+			g.Lines = [][]Point{line}
+		}
+	case TypeMultiLineString:
+		var pos [][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			break
+		}
+		g.Lines, err = rings(pos)
+	case TypePolygon:
+		var pos [][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			break
+		}
+		var poly [][]Point
+		if poly, err = rings(pos); err == nil {
+			g.Polygons = [][][]Point{poly}
+		}
+	case TypeMultiPolygon:
+		var pos [][][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			break
+		}
+		for _, p := range pos {
+			var poly [][]Point
+			if poly, err = rings(p); err != nil {
+				break
+			}
+			g.Polygons = append(g.Polygons, poly)
+		}
+	case TypeGeometryCollection:
+		for _, o := range obj.Geometries {
+			var sub Geometry
+			if sub, err = geometry(o); err != nil {
+				break
+			}
+			g.Geometries = append(g.Geometries, sub)
+		}
+	default:
+		err = fmt.Errorf("%w: %s", ErrType, obj.Type)
+	}
+	return g, err
+}
+
+func rings(list [][][]float64) ([][]Point, error) {
+	var all [][]Point
+	for _, r := range list {
+		pts, err := positions(r)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, pts)
+	}
+	return all, nil
+}
+
+func positions(list [][]float64) ([]Point, error) {
+	pts := make([]Point, 0, len(list))
+	for _, pos := range list {
+		pt, err := position(pos)
+		if err != nil {
+			return nil, err
+		}
+		pts = append(pts, pt)
+	}
+	return pts, nil
+}
+
+func position(pos []float64) (Point, error) {
+	if len(pos) < 2 {
+		return Point{}, fmt.Errorf("%w: %v", ErrPosition, pos)
+	}
+	return Point{Lon: pos[0], Lat: pos[1]}, nil
+}
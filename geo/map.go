@@ -0,0 +1,164 @@
+package geo
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/chart"
+)
+
+const (
+	defaultWidth  = 800
+	defaultHeight = 600
+	defaultMargin = 10
+	defaultFill   = "#dddddd"
+	defaultStroke = "#ffffff"
+)
+
+type Map struct {
+	Features   []Feature
+	Projection Projection
+	Width      float64
+	Height     float64
+	Margin     float64
+	Fill       string
+	Stroke     string
+	Properties []string
+}
+
+func (m Map) Render(w io.Writer) error {
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := m.Element()
+	el.Render(ws)
+	return nil
+}
+
+func (m Map) Element() svg.Element {
+	var (
+		canvas = m.canvas()
+		proj   = m.Fit(canvas.Dim)
+	)
+	canvas.Append(m.features(proj, nil))
+	return canvas.AsElement()
+}
+
+func (m Map) Fit(dim svg.Dim) Projection {
+	return m.fit(svg.NewPos(0, 0), dim)
+}
+
+func (m Map) fit(pos svg.Pos, dim svg.Dim) Projection {
+	proj := m.Projection
+	if proj.Scale != 0 {
+		return proj
+	}
+	margin := m.margin()
+	inner := svg.NewDim(dim.W-2*margin, dim.H-2*margin)
+	proj = proj.Fit(inner, m.Features)
+	proj.Translate = proj.Translate.Adjust(pos.X+margin, pos.Y+margin)
+	return proj
+}
+
+func (m Map) canvas() svg.SVG {
+	canvas := svg.NewSVG()
+	canvas.Dim = m.dim()
+	return canvas
+}
+
+func (m Map) features(proj Projection, style func(Feature, *svg.Path)) svg.Element {
+	var grp svg.Group
+	grp.Class = append(grp.Class, "features")
+	for _, f := range m.Features {
+		path := proj.Path(f.Geometry)
+		path.Class = append(path.Class, "feature")
+		path.Fill = svg.NewFill(m.fill())
+		path.Stroke = svg.NewStroke(m.stroke(), 0.5)
+		path.Data = m.data(f)
+		if f.Geometry.lineal() {
+			path.Fill = svg.NewFill("none")
+			path.Stroke = svg.NewStroke(m.fill(), 1)
+		}
+		if style != nil {
+			style(f, &path)
+		}
+		grp.Append(path.AsElement())
+	}
+	return grp.AsElement()
+}
+
+func (m Map) region(size svg.Dim, where chart.Placement) (svg.Pos, svg.Dim) {
+	var (
+		pos    = svg.NewPos(0, 0)
+		dim    = m.dim()
+		margin = m.margin()
+	)
+	switch where {
+	case chart.PlaceRight:
+		dim.W -= size.W + margin
+	case chart.PlaceLeft:
+		pos.X += size.W + margin
+		dim.W -= size.W + margin
+	case chart.PlaceTop:
+		pos.Y += size.H + margin
+		dim.H -= size.H + margin
+	case chart.PlaceBottom:
+		dim.H -= size.H + margin
+	}
+	return pos, dim
+}
+
+func (m Map) data(f Feature) []svg.Datum {
+	var list []svg.Datum
+	if f.Id != "" {
+		list = append(list, svg.Datum{Name: "id", Value: f.Id})
+	}
+	if name := f.Property("name"); name != "" {
+		list = append(list, svg.Datum{Name: "name", Value: name})
+	}
+	for _, p := range m.Properties {
+		if p == "name" {
+			continue
+		}
+		if v := f.Property(p); v != "" {
+			list = append(list, svg.Datum{Name: p, Value: v})
+		}
+	}
+	return list
+}
+
+func (m Map) dim() svg.Dim {
+	d := svg.NewDim(m.Width, m.Height)
+	if d.W <= 0 {
+		d.W = defaultWidth
+	}
+	if d.H <= 0 {
+		d.H = defaultHeight
+	}
+	return d
+}
+
+func (m Map) margin() float64 {
+	if m.Margin < 0 {
+		return 0
+	}
+	if m.Margin == 0 {
+		return defaultMargin
+	}
+	return m.Margin
+}
+
+func (m Map) fill() string {
+	if m.Fill == "" {
+		return defaultFill
+	}
+	return m.Fill
+}
+
+func (m Map) stroke() string {
+	if m.Stroke == "" {
+		return defaultStroke
+	}
+	return m.Stroke
+}
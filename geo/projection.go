@@ -0,0 +1,364 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/midbel/svg"
+)
+
+const (
+	epsilon       = 1e-6
+	defaultScale  = 150
+	mercatorLimit = 85.0511287798
+)
+
+type Raw interface {
+	Forward(lambda, phi float64) (float64, float64)
+}
+
+type horizon interface {
+	horizon() float64
+}
+
+type Projection struct {
+	Raw
+	Scale     float64
+	Translate svg.Pos
+	Lon0      float64
+	Lat0      float64
+}
+
+func NewEquirectangular() Projection {
+	return Projection{Raw: equirectangular{}}
+}
+
+func NewMercator() Projection {
+	return Projection{Raw: mercator{}}
+}
+
+func NewOrthographic() Projection {
+	return Projection{Raw: orthographic{}}
+}
+
+func NewAlbers(phi1, phi2 float64) Projection {
+	var (
+		sy0 = math.Sin(radians(phi1))
+		n   = (sy0 + math.Sin(radians(phi2))) / 2
+	)
+	if math.Abs(n) < epsilon {
+		return Projection{Raw: cylindricalEqualArea{cosPhi: math.Cos(radians(phi1))}}
+	}
+	c := 1 + sy0*(2*n-sy0)
+	return Projection{
+		Raw: albers{
+			n:    n,
+			c:    c,
+			rho0: math.Sqrt(c) / n,
+		},
+	}
+}
+
+func NewLambert(phi1, phi2 float64) Projection {
+	var (
+		y0  = radians(phi1)
+		y1  = radians(phi2)
+		cy0 = math.Cos(y0)
+		n   = math.Sin(y0)
+	)
+	if y0 != y1 {
+		n = math.Log(cy0/math.Cos(y1)) / math.Log(tany(y1)/tany(y0))
+	}
+	if math.Abs(n) < epsilon {
+		return NewMercator()
+	}
+	return Projection{
+		Raw: lambert{
+			n: n,
+			f: cy0 * math.Pow(tany(y0), n) / n,
+		},
+	}
+}
+
+func (p Projection) Project(lon, lat float64) (svg.Pos, bool) {
+	lon, lat = p.rotate(lon, lat)
+	if h := p.horizon(); math.Abs(lon) > h {
+		return svg.Pos{}, false
+	}
+	return p.forward(lon, lat), true
+}
+
+func (p Projection) Path(g Geometry) svg.Path {
+	var path svg.Path
+	p.stream(&path, g)
+	return path
+}
+
+func (p Projection) Fit(dim svg.Dim, features []Feature) Projection {
+	p.Scale = 1
+	p.Translate = svg.NewPos(0, 0)
+	var (
+		minx = math.Inf(1)
+		miny = math.Inf(1)
+		maxx = math.Inf(-1)
+		maxy = math.Inf(-1)
+	)
+	for _, f := range features {
+		p.each(f.Geometry, func(pos svg.Pos) {
+			minx = math.Min(minx, pos.X)
+			maxx = math.Max(maxx, pos.X)
+			miny = math.Min(miny, pos.Y)
+			maxy = math.Max(maxy, pos.Y)
+		})
+	}
+	if math.IsInf(minx, 0) || math.IsInf(miny, 0) {
+		p.Scale = defaultScale
+		p.Translate = svg.NewPos(dim.W/2, dim.H/2)
+		return p
+	}
+	var (
+		w = maxx - minx
+		h = maxy - miny
+		k = math.Inf(1)
+	)
+	if w > 0 {
+		k = dim.W / w
+	}
+	if h > 0 {
+		k = math.Min(k, dim.H/h)
+	}
+	if math.IsInf(k, 0) {
+		k = defaultScale
+	}
+	p.Scale = k
+	p.Translate = svg.NewPos(
+		(dim.W-k*(maxx+minx))/2,
+		(dim.H-k*(maxy+miny))/2,
+	)
+	return p
+}
+
+func (p Projection) Centroid(g Geometry) (svg.Pos, bool) {
+	var c centroid
+	p.centroid(&c, g)
+	switch {
+	case c.area > 0:
+		return svg.NewPos(c.ax/c.area, c.ay/c.area), true
+	case c.length > 0:
+		return svg.NewPos(c.lx/c.length, c.ly/c.length), true
+	case c.count > 0:
+		return svg.NewPos(c.px/c.count, c.py/c.count), true
+	default:
+		return svg.Pos{}, false
+	}
+}
+
+type centroid struct {
+	area   float64
+	ax     float64
+	ay     float64
+	length float64
+	lx     float64
+	ly     float64
+	count  float64
+	px     float64
+	py     float64
+}
+
+func (p Projection) centroid(c *centroid, g Geometry) {
+	for _, pt := range g.Points {
+		if pos, ok := p.Project(pt.Lon, pt.Lat); ok {
+			c.count++
+			c.px += pos.X
+			c.py += pos.Y
+		}
+	}
+	for _, line := range g.Lines {
+		for _, part := range p.clipLine(line) {
+			for i := 1; i < len(part); i++ {
+				var (
+					a = p.forward(part[i-1].Lon, part[i-1].Lat)
+					b = p.forward(part[i].Lon, part[i].Lat)
+					d = math.Hypot(b.X-a.X, b.Y-a.Y)
+				)
+				c.length += d
+				c.lx += d * (a.X + b.X) / 2
+				c.ly += d * (a.Y + b.Y) / 2
+			}
+		}
+	}
+	for _, poly := range g.Polygons {
+		for i, ring := range poly {
+			sign := 1.0
+			if i > 0 {
+				sign = -1
+			}
+			for _, part := range p.clipRing(ring) {
+				var area, ax, ay float64
+				for j := range part {
+					var (
+						a     = p.forward(part[j].Lon, part[j].Lat)
+						b     = p.forward(part[(j+1)%len(part)].Lon, part[(j+1)%len(part)].Lat)
+						cross = a.X*b.Y - b.X*a.Y
+					)
+					area += cross
+					ax += (a.X + b.X) * cross
+					ay += (a.Y + b.Y) * cross
+				}
+				if area == 0 {
+					continue
+				}
+				k := sign * math.Abs(area) / area
+				c.area += k * area / 2
+				c.ax += k * ax / 6
+				c.ay += k * ay / 6
+			}
+		}
+	}
+	for _, sub := range g.Geometries {
+		p.centroid(c, sub)
+	}
+}
+
+func (p Projection) each(g Geometry, fn func(svg.Pos)) {
+	for _, pt := range g.Points {
+		if pos, ok := p.Project(pt.Lon, pt.Lat); ok {
+			fn(pos)
+		}
+	}
+	for _, line := range g.Lines {
+		for _, part := range p.clipLine(line) {
+			for _, pt := range part {
+				fn(p.forward(pt.Lon, pt.Lat))
+			}
+		}
+	}
+	for _, poly := range g.Polygons {
+		for _, ring := range poly {
+			for _, part := range p.clipRing(ring) {
+				for _, pt := range part {
+					fn(p.forward(pt.Lon, pt.Lat))
+				}
+			}
+		}
+	}
+	for _, sub := range g.Geometries {
+		p.each(sub, fn)
+	}
+}
+
+func (p Projection) forward(lon, lat float64) svg.Pos {
+	raw := p.Raw
+	if raw == nil {
+		raw = equirectangular{}
+	}
+	var (
+		x, y = raw.Forward(radians(lon), radians(lat))
+		k    = p.scale()
+	)
+	return svg.NewPos(p.Translate.X+k*x, p.Translate.Y-k*y)
+}
+
+func (p Projection) rotate(lon, lat float64) (float64, float64) {
+	lambda := radians(lon - p.Lon0)
+	lambda = math.Atan2(math.Sin(lambda), math.Cos(lambda))
+	if p.Lat0 == 0 {
+		return degrees(lambda), lat
+	}
+	var (
+		phi  = radians(lat)
+		dphi = radians(-p.Lat0)
+		cdp  = math.Cos(dphi)
+		sdp  = math.Sin(dphi)
+		x    = math.Cos(lambda) * math.Cos(phi)
+		y    = math.Sin(lambda) * math.Cos(phi)
+		z    = math.Sin(phi)
+		k    = z*cdp + x*sdp
+	)
+	lambda = math.Atan2(y, x*cdp-z*sdp)
+	phi = math.Asin(math.Max(-1, math.Min(1, k)))
+	return degrees(lambda), degrees(phi)
+}
+
+func (p Projection) horizon() float64 {
+	if h, ok := p.Raw.(horizon); ok {
+		return h.horizon()
+	}
+	return 180
+}
+
+func (p Projection) scale() float64 {
+	if p.Scale == 0 {
+		return defaultScale
+	}
+	return p.Scale
+}
+
+type equirectangular struct{}
+
+func (equirectangular) Forward(lambda, phi float64) (float64, float64) {
+	return lambda, phi
+}
+
+type mercator struct{}
+
+func (mercator) Forward(lambda, phi float64) (float64, float64) {
+	limit := radians(mercatorLimit)
+	phi = math.Max(-limit, math.Min(limit, phi))
+	return lambda, math.Log(math.Tan((math.Pi/2 + phi) / 2))
+}
+
+type orthographic struct{}
+
+func (orthographic) Forward(lambda, phi float64) (float64, float64) {
+	return math.Cos(phi) * math.Sin(lambda), math.Sin(phi)
+}
+
+func (orthographic) horizon() float64 {
+	return 90
+}
+
+type cylindricalEqualArea struct {
+	cosPhi float64
+}
+
+func (c cylindricalEqualArea) Forward(lambda, phi float64) (float64, float64) {
+	return lambda * c.cosPhi, math.Sin(phi) / c.cosPhi
+}
+
+type albers struct {
+	n    float64
+	c    float64
+	rho0 float64
+}
+
+func (a albers) Forward(lambda, phi float64) (float64, float64) {
+	rho := math.Sqrt(math.Max(0, a.c-2*a.n*math.Sin(phi))) / a.n
+	return rho * math.Sin(lambda*a.n), a.rho0 - rho*math.Cos(lambda*a.n)
+}
+
+type lambert struct {
+	n float64
+	f float64
+}
+
+func (l lambert) Forward(lambda, phi float64) (float64, float64) {
+	if l.f > 0 {
+		phi = math.Max(phi, -math.Pi/2+epsilon)
+	} else {
+		phi = math.Min(phi, math.Pi/2-epsilon)
+	}
+	rho := l.f / math.Pow(tany(phi), l.n)
+	return rho * math.Sin(l.n*lambda), l.f - rho*math.Cos(l.n*lambda)
+}
+
+func tany(phi float64) float64 {
+	return math.Tan((math.Pi/2 + phi) / 2)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
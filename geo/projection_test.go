@@ -0,0 +1,174 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/midbel/svg"
+)
+
+const tolerance = 1e-6
+
+func TestRawForward(t *testing.T) {
+	data := []struct {
+		Name string
+		Raw  Raw
+		Lon  float64
+		Lat  float64
+		X    float64
+		Y    float64
+	}{
+		{Name: "equirectangular", Raw: equirectangular{}, Lon: 90, Lat: 45, X: math.Pi / 2, Y: math.Pi / 4},
+		{Name: "mercator", Raw: mercator{}, Lon: 0, Lat: 45, X: 0, Y: 0.881373587},
+		{Name: "mercator", Raw: mercator{}, Lon: 180, Lat: mercatorLimit, X: math.Pi, Y: math.Pi},
+		{Name: "mercator", Raw: mercator{}, Lon: -180, Lat: -90, X: -math.Pi, Y: -math.Pi},
+		{Name: "orthographic", Raw: orthographic{}, Lon: 90, Lat: 0, X: 1, Y: 0},
+		{Name: "orthographic", Raw: orthographic{}, Lon: 45, Lat: 45, X: 0.5, Y: math.Sqrt2 / 2},
+		{Name: "cylindrical", Raw: NewAlbers(-30, 30).Raw, Lon: 90, Lat: 30, X: math.Pi / 2 * math.Sqrt(3) / 2, Y: 1 / math.Sqrt(3)},
+	}
+	for _, d := range data {
+		x, y := d.Raw.Forward(radians(d.Lon), radians(d.Lat))
+		if !near(x, d.X) || !near(y, d.Y) {
+			t.Errorf("%s(%f, %f): want (%f, %f), got (%f, %f)", d.Name, d.Lon, d.Lat, d.X, d.Y, x, y)
+		}
+	}
+}
+
+func TestConicStandardParallels(t *testing.T) {
+	const (
+		phi1 = 29.5
+		phi2 = 45.5
+		step = 1e-4
+	)
+	data := []struct {
+		Name string
+		Proj Projection
+	}{
+		{Name: "albers", Proj: NewAlbers(phi1, phi2)},
+		{Name: "lambert", Proj: NewLambert(phi1, phi2)},
+	}
+	for _, d := range data {
+		for _, phi := range []float64{phi1, phi2} {
+			var (
+				x0, y0 = d.Proj.Forward(0, radians(phi))
+				x1, y1 = d.Proj.Forward(step, radians(phi))
+				got    = math.Hypot(x1-x0, y1-y0) / step
+				want   = math.Cos(radians(phi))
+			)
+			if math.Abs(got-want) > 1e-4 {
+				t.Errorf("%s: scale along parallel %f: want %f, got %f", d.Name, phi, want, got)
+			}
+		}
+	}
+	if _, ok := NewLambert(-30, 30).Raw.(mercator); !ok {
+		t.Errorf("lambert with symmetric parallels should fall back to mercator")
+	}
+}
+
+func TestProject(t *testing.T) {
+	data := []struct {
+		Name    string
+		Proj    Projection
+		Lon     float64
+		Lat     float64
+		Want    svg.Pos
+		Visible bool
+	}{
+		{
+			Name:    "equirectangular",
+			Proj:    Projection{Raw: equirectangular{}, Scale: 150, Translate: svg.NewPos(480, 250)},
+			Lon:     90,
+			Lat:     45,
+			Want:    svg.NewPos(480+150*math.Pi/2, 250-150*math.Pi/4),
+			Visible: true,
+		},
+		{
+			Name:    "rotated",
+			Proj:    Projection{Raw: equirectangular{}, Scale: 100, Lon0: 90},
+			Lon:     -90,
+			Lat:     0,
+			Want:    svg.NewPos(-100*math.Pi, 0),
+			Visible: true,
+		},
+		{
+			Name:    "orthographic",
+			Proj:    Projection{Raw: orthographic{}, Scale: 100},
+			Lon:     120,
+			Lat:     0,
+			Visible: false,
+		},
+		{
+			Name:    "polar",
+			Proj:    Projection{Raw: orthographic{}, Scale: 100, Lat0: 90},
+			Lon:     0,
+			Lat:     90,
+			Want:    svg.NewPos(0, 0),
+			Visible: true,
+		},
+		{
+			Name:    "polar",
+			Proj:    Projection{Raw: orthographic{}, Scale: 100, Lat0: 90},
+			Lon:     0,
+			Lat:     45,
+			Want:    svg.NewPos(0, 100*math.Sqrt2/2),
+			Visible: true,
+		},
+		{
+			Name:    "polar",
+			Proj:    Projection{Raw: orthographic{}, Scale: 100, Lat0: 90},
+			Lon:     0,
+			Lat:     -10,
+			Visible: false,
+		},
+	}
+	for _, d := range data {
+		pos, ok := d.Proj.Project(d.Lon, d.Lat)
+		if ok != d.Visible {
+			t.Errorf("%s(%f, %f): want visible %t, got %t", d.Name, d.Lon, d.Lat, d.Visible, ok)
+			continue
+		}
+		if ok && (!near(pos.X, d.Want.X) || !near(pos.Y, d.Want.Y)) {
+			t.Errorf("%s(%f, %f): want %v, got %v", d.Name, d.Lon, d.Lat, d.Want, pos)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	var (
+		ring = []Point{{Lon: -10, Lat: -5}, {Lon: 10, Lat: -5}, {Lon: 10, Lat: 5}, {Lon: -10, Lat: 5}, {Lon: -10, Lat: -5}}
+		list = []Feature{{Geometry: Geometry{Polygons: [][][]Point{{ring}}}}}
+		proj = NewEquirectangular().Fit(svg.NewDim(200, 100), list)
+	)
+	for _, c := range []struct {
+		Lon  float64
+		Lat  float64
+		Want svg.Pos
+	}{
+		{Lon: -10, Lat: 5, Want: svg.NewPos(0, 0)},
+		{Lon: 10, Lat: -5, Want: svg.NewPos(200, 100)},
+		{Lon: 0, Lat: 0, Want: svg.NewPos(100, 50)},
+	} {
+		pos, _ := proj.Project(c.Lon, c.Lat)
+		if !near(pos.X, c.Want.X) || !near(pos.Y, c.Want.Y) {
+			t.Errorf("(%f, %f): want %v, got %v", c.Lon, c.Lat, c.Want, pos)
+		}
+	}
+}
+
+func TestClipLineAntimeridian(t *testing.T) {
+	parts := NewEquirectangular().clipLine([]Point{{Lon: 170, Lat: 0}, {Lon: -170, Lat: 10}})
+	if len(parts) != 2 {
+		t.Fatalf("want line split in 2 parts, got %d", len(parts))
+	}
+	var (
+		end   = parts[0][len(parts[0])-1]
+		start = parts[1][0]
+	)
+	if !near(end.Lon, 180) || !near(start.Lon, -180) || !near(end.Lat, 5) || !near(start.Lat, 5) {
+		t.Errorf("line should cross the antimeridian at latitude 5: got %v and %v", end, start)
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < tolerance*math.Max(1, math.Abs(b))
+}
@@ -7,6 +7,40 @@ type Stop struct {
 	Offset  float64
 }
 
+func NewStop(offset float64, color string) Stop {
+	return Stop{
+		Offset: offset,
+		Color:  color,
+	}
+}
+
+func (s *Stop) Render(w Writer) {
+	writeElement(w, "stop", s.Attributes(), nil)
+}
+
+func (s *Stop) tag() (string, []Attribute) {
+	return "stop", []Attribute{s}
+}
+
+func (s *Stop) AsElement() Element {
+	return s
+}
+
+func (s *Stop) Attributes() []string {
+	var attrs []string
+	attrs = append(attrs, appendFloat("offset", s.Offset))
+	if s.Color != "" {
+		attrs = append(attrs, appendString("stop-color", s.Color))
+	}
+	if s.Opacity > 0 {
+		attrs = append(attrs, appendFloat("stop-opacity", s.Opacity))
+	}
+	if len(s.Class) > 0 {
+		attrs = append(attrs, appendStringArray("class", s.Class, space))
+	}
+	return attrs
+}
+
 type Linear struct {
 	node
 	List
@@ -14,16 +48,39 @@ type Linear struct {
 	Pos1   Pos
 	Pos2   Pos
 	Spread string
+	Units  string
 }
 
 func (i *Linear) Render(w Writer) {
-	i.render(w, "linearGradient", i.List)
+	name, attrs := i.tag()
+	i.render(w, name, i.List, attrs...)
+}
+
+func (i *Linear) tag() (string, []Attribute) {
+	return "linearGradient", []Attribute{i}
 }
 
 func (i *Linear) AsElement() Element {
 	return i
 }
 
+func (i *Linear) Attributes() []string {
+	var attrs []string
+	if !i.Pos1.IsZero() || !i.Pos2.IsZero() {
+		attrs = append(attrs, appendFloat("x1", i.Pos1.X))
+		attrs = append(attrs, appendFloat("y1", i.Pos1.Y))
+		attrs = append(attrs, appendFloat("x2", i.Pos2.X))
+		attrs = append(attrs, appendFloat("y2", i.Pos2.Y))
+	}
+	if i.Spread != "" {
+		attrs = append(attrs, appendString("spreadMethod", i.Spread))
+	}
+	if i.Units != "" {
+		attrs = append(attrs, appendString("gradientUnits", i.Units))
+	}
+	return attrs
+}
+
 type Radial struct {
 	node
 	List
@@ -37,7 +94,12 @@ type Radial struct {
 }
 
 func (r *Radial) Render(w Writer) {
-	r.render(w, "radialGradient", r.List)
+	name, attrs := r.tag()
+	r.render(w, name, r.List, attrs...)
+}
+
+func (r *Radial) tag() (string, []Attribute) {
+	return "radialGradient", nil
 }
 
 func (r *Radial) AsElement() Element {
@@ -46,4 +108,38 @@ func (r *Radial) AsElement() Element {
 
 type Pattern struct {
 	node
+	List
+
+	Pos
+	Dim
+	ViewBox
+	Units        string
+	ContentUnits string
+}
+
+func (p *Pattern) Render(w Writer) {
+	name, attrs := p.tag()
+	p.render(w, name, p.List, attrs...)
+}
+
+func (p *Pattern) tag() (string, []Attribute) {
+	return "pattern", []Attribute{p, p.Pos, p.Dim}
+}
+
+func (p *Pattern) AsElement() Element {
+	return p
+}
+
+func (p *Pattern) Attributes() []string {
+	var attrs []string
+	if !p.ViewBox.IsZero() {
+		attrs = append(attrs, p.ViewBox.Attributes()...)
+	}
+	if p.Units != "" {
+		attrs = append(attrs, appendString("patternUnits", p.Units))
+	}
+	if p.ContentUnits != "" {
+		attrs = append(attrs, appendString("patternContentUnits", p.ContentUnits))
+	}
+	return attrs
 }
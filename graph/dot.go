@@ -0,0 +1,384 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+)
+
+const (
+	tokEOF rune = -(iota + 1)
+	tokIdent
+	tokEdge
+)
+
+type token struct {
+	kind    rune
+	literal string
+	line    int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokIdent, tokEdge:
+		return t.literal
+	default:
+		return string(t.kind)
+	}
+}
+
+func ParseDOT(r io.Reader) (Graph, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Graph{}, err
+	}
+	p := parser{
+		input: []rune(string(buf)),
+		line:  1,
+	}
+	p.next()
+	return p.parse()
+}
+
+type parser struct {
+	input []rune
+	pos   int
+	line  int
+	curr  token
+	graph Graph
+}
+
+func (p *parser) parse() (Graph, error) {
+	if p.is("strict") {
+		p.next()
+	}
+	switch {
+	case p.is("digraph"):
+	case p.is("graph"):
+		p.graph.Undirected = true
+	default:
+		return p.graph, p.unexpected()
+	}
+	p.next()
+	if p.curr.kind == tokIdent {
+		p.next()
+	}
+	if err := p.block(); err != nil {
+		return p.graph, err
+	}
+	if p.curr.kind != tokEOF {
+		return p.graph, p.unexpected()
+	}
+	return p.graph, nil
+}
+
+func (p *parser) block() error {
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+	for p.curr.kind != '}' {
+		if p.curr.kind == tokEOF {
+			return p.unexpected()
+		}
+		if err := p.statement(); err != nil {
+			return err
+		}
+		if p.curr.kind == ';' || p.curr.kind == ',' {
+			p.next()
+		}
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) statement() error {
+	switch {
+	case p.is("graph") || p.is("node") || p.is("edge"):
+		p.next()
+		_, err := p.attributes()
+		return err
+	case p.curr.kind == tokIdent && !p.is("subgraph"):
+		id := p.curr.literal
+		p.next()
+		if p.curr.kind == '=' {
+			p.next()
+			if p.curr.kind != tokIdent {
+				return p.unexpected()
+			}
+			p.next()
+			return nil
+		}
+		p.port()
+		if p.curr.kind == tokEdge {
+			return p.edges([]string{id})
+		}
+		attrs, err := p.attributes()
+		if err != nil {
+			return err
+		}
+		n := Node{
+			Id:    id,
+			Label: attrs["label"],
+			Color: attrs["fillcolor"],
+		}
+		if n.Color == "" {
+			n.Color = attrs["color"]
+		}
+		p.graph.AddNode(n)
+		return nil
+	case p.is("subgraph") || p.curr.kind == '{':
+		list, err := p.subgraph()
+		if err != nil || p.curr.kind != tokEdge {
+			return err
+		}
+		return p.edges(list)
+	default:
+		return p.unexpected()
+	}
+}
+
+func (p *parser) edges(sources []string) error {
+	var chain [][2][]string
+	for p.curr.kind == tokEdge {
+		p.next()
+		targets, err := p.operand()
+		if err != nil {
+			return err
+		}
+		chain = append(chain, [2][]string{sources, targets})
+		sources = targets
+	}
+	attrs, err := p.attributes()
+	if err != nil {
+		return err
+	}
+	for _, c := range chain {
+		for _, src := range c[0] {
+			for _, dst := range c[1] {
+				p.graph.AddEdge(Edge{
+					Source: src,
+					Target: dst,
+					Label:  attrs["label"],
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) operand() ([]string, error) {
+	switch {
+	case p.is("subgraph") || p.curr.kind == '{':
+		return p.subgraph()
+	case p.curr.kind == tokIdent:
+		id := p.curr.literal
+		p.next()
+		p.port()
+		return []string{id}, nil
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+func (p *parser) subgraph() ([]string, error) {
+	if p.is("subgraph") {
+		p.next()
+		if p.curr.kind == tokIdent {
+			p.next()
+		}
+	}
+	var (
+		tmp  = p.graph
+		list []string
+	)
+	p.graph = Graph{Undirected: tmp.Undirected}
+	err := p.block()
+	inner := p.graph
+	p.graph = tmp
+	for _, n := range inner.Nodes {
+		list = append(list, n.Id)
+		p.graph.AddNode(n)
+	}
+	p.graph.Edges = append(p.graph.Edges, inner.Edges...)
+	return list, err
+}
+
+func (p *parser) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for p.curr.kind == '[' {
+		p.next()
+		for p.curr.kind != ']' {
+			if p.curr.kind != tokIdent {
+				return nil, p.unexpected()
+			}
+			key := p.curr.literal
+			p.next()
+			if err := p.expect('='); err != nil {
+				return nil, err
+			}
+			if p.curr.kind != tokIdent {
+				return nil, p.unexpected()
+			}
+			attrs[key] = p.curr.literal
+			p.next()
+			if p.curr.kind == ',' || p.curr.kind == ';' {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	return attrs, nil
+}
+
+func (p *parser) port() {
+	for p.curr.kind == ':' {
+		p.next()
+		if p.curr.kind == tokIdent {
+			p.next()
+		}
+	}
+}
+
+func (p *parser) is(keyword string) bool {
+	return p.curr.kind == tokIdent && strings.EqualFold(p.curr.literal, keyword)
+}
+
+func (p *parser) expect(kind rune) error {
+	if p.curr.kind != kind {
+		return p.unexpected()
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	return fmt.Errorf("%w: line %d: unexpected %s", ErrSyntax, p.curr.line, p.curr)
+}
+
+func (p *parser) next() {
+	p.skip()
+	tok := token{line: p.line}
+	if p.pos >= len(p.input) {
+		tok.kind = tokEOF
+		p.curr = tok
+		return
+	}
+	c := p.input[p.pos]
+	switch {
+	case c == '"':
+		tok.kind = tokIdent
+		tok.literal = p.quoted()
+	case c == '<':
+		tok.kind = tokIdent
+		tok.literal = p.html()
+	case c == '-' && p.peek() == '>' || c == '-' && p.peek() == '-':
+		tok.kind = tokEdge
+		tok.literal = string(p.input[p.pos : p.pos+2])
+		p.pos += 2
+	case isIdent(c) || c == '-' && unicode.IsDigit(p.peek()):
+		tok.kind = tokIdent
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.input) && isIdent(p.input[p.pos]) {
+			p.pos++
+		}
+		tok.literal = string(p.input[start:p.pos])
+	default:
+		tok.kind = c
+		p.pos++
+	}
+	p.curr = tok
+}
+
+func (p *parser) quoted() string {
+	var buf bytes.Buffer
+	for p.pos++; p.pos < len(p.input); p.pos++ {
+		c := p.input[p.pos]
+		if c == '"' {
+			p.pos++
+			break
+		}
+		if c == '\\' && p.pos+1 < len(p.input) {
+			p.pos++
+			switch c = p.input[p.pos]; c {
+			case 'n', 'l', 'r':
+				c = '\n'
+			case '\n':
+				p.line++
+				continue
+			}
+		}
+		if c == '\n' {
+			p.line++
+		}
+		buf.WriteRune(c)
+	}
+	return buf.String()
+}
+
+func (p *parser) html() string {
+	var (
+		depth int
+		start = p.pos + 1
+	)
+	for ; p.pos < len(p.input); p.pos++ {
+		switch p.input[p.pos] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case '\n':
+			p.line++
+		}
+		if depth == 0 {
+			p.pos++
+			return string(p.input[start : p.pos-1])
+		}
+	}
+	return string(p.input[start:])
+}
+
+func (p *parser) skip() {
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case unicode.IsSpace(c):
+			p.pos++
+		case c == '#' || c == '/' && p.peek() == '/':
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == '/' && p.peek() == '*':
+			for p.pos += 2; p.pos < len(p.input); p.pos++ {
+				if p.input[p.pos] == '\n' {
+					p.line++
+				}
+				if p.input[p.pos] == '*' && p.peek() == '/' {
+					p.pos += 2
+					break
+				}
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) peek() rune {
+	if p.pos+1 >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos+1]
+}
+
+func isIdent(c rune) bool {
+	return c == '_' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
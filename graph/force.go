@@ -0,0 +1,436 @@
+package graph
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/midbel/svg"
+	"github.com/midbel/svg/chart"
+)
+
+const (
+	defaultForceIterations = 300
+	defaultForceWidth      = 800
+	defaultForceHeight     = 600
+	defaultNodeRadius      = 6
+	defaultGravity         = 0.1
+	defaultTheta           = 0.9
+	minDistance            = 0.01
+)
+
+type Force struct {
+	Graph
+	Width      float64
+	Height     float64
+	Iterations int
+	Seed       int64
+	Repulsion  float64
+	Attraction float64
+	Gravity    float64
+	Theta      float64
+	Pinned     map[string]svg.Pos
+	Radius     float64
+	Font       svg.Font
+	OmitLabels bool
+}
+
+func (f Force) Render(w io.Writer) error {
+	d, err := f.Layout()
+	if err != nil {
+		return err
+	}
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := f.render(d)
+	el.Render(ws)
+	return nil
+}
+
+func (f Force) Element() svg.Element {
+	d, _ := f.Layout()
+	return f.render(d)
+}
+
+func (f Force) Layout() (Drawing, error) {
+	var d Drawing
+	index, err := f.index()
+	if err != nil {
+		return d, err
+	}
+	var (
+		width, height = f.size()
+		radius        = f.radius()
+		count         = len(f.Nodes)
+		rng           = rand.New(rand.NewSource(f.Seed))
+		pos           = make([]svg.Pos, count)
+		disp          = make([]svg.Pos, count)
+		pinned        = make([]bool, count)
+		center        = svg.NewPos(width/2, height/2)
+		gravity       = f.gravity() * float64(len(f.Nodes))
+		iter          = f.Iterations
+	)
+	if count == 0 {
+		return d, nil
+	}
+	if iter <= 0 {
+		iter = defaultForceIterations
+	}
+	var (
+		k    = math.Sqrt(width * height / float64(count))
+		temp = width / 10
+	)
+	for i, n := range f.Nodes {
+		if p, ok := f.Pinned[n.Id]; ok {
+			pos[i] = p
+			pinned[i] = true
+			continue
+		}
+		pos[i] = svg.NewPos(radius+rng.Float64()*(width-2*radius), radius+rng.Float64()*(height-2*radius))
+	}
+	for i := 0; i < iter; i++ {
+		for j := range disp {
+			disp[j] = svg.Pos{}
+		}
+		f.repulse(pos, disp, k, rng)
+		for _, e := range f.Edges {
+			var (
+				u, v   = index[e.Source], index[e.Target]
+				dx, dy = pos[v].X - pos[u].X, pos[v].Y - pos[u].Y
+				dist   = math.Max(math.Hypot(dx, dy), minDistance)
+				force  = dist * dist / k * f.attraction()
+			)
+			if u == v {
+				continue
+			}
+			disp[v].X -= dx / dist * force
+			disp[v].Y -= dy / dist * force
+			disp[u].X += dx / dist * force
+			disp[u].Y += dy / dist * force
+		}
+		for j := range pos {
+			if pinned[j] {
+				continue
+			}
+			disp[j].X += (center.X - pos[j].X) * gravity
+			disp[j].Y += (center.Y - pos[j].Y) * gravity
+
+			length := math.Hypot(disp[j].X, disp[j].Y)
+			if length < minDistance {
+				continue
+			}
+			step := math.Min(length, temp)
+			pos[j].X += disp[j].X / length * step
+			pos[j].Y += disp[j].Y / length * step
+		}
+		temp = width / 10 * (1 - float64(i+1)/float64(iter))
+	}
+	if len(f.Pinned) == 0 {
+		fit(pos, svg.NewPos(radius, radius), svg.NewDim(width-2*radius, height-2*radius))
+	}
+	for j := range pos {
+		pos[j].X = math.Max(radius, math.Min(width-radius, pos[j].X))
+		pos[j].Y = math.Max(radius, math.Min(height-radius, pos[j].Y))
+	}
+	for i, n := range f.Nodes {
+		d.Nodes = append(d.Nodes, Box{
+			Node: n,
+			X:    pos[i].X - radius,
+			Y:    pos[i].Y - radius,
+			W:    2 * radius,
+			H:    2 * radius,
+		})
+	}
+	for _, e := range f.Edges {
+		d.Edges = append(d.Edges, Route{
+			Edge:   e,
+			Points: []svg.Pos{pos[index[e.Source]], pos[index[e.Target]]},
+		})
+	}
+	d.Width, d.Height = width, height
+	return d, nil
+}
+
+func (f Force) repulse(pos, disp []svg.Pos, k float64, rng *rand.Rand) {
+	strength := k * k * f.repulsion()
+	push := func(i int, dx, dy, mass float64) {
+		if dx == 0 && dy == 0 {
+			dx, dy = rng.Float64()-0.5, rng.Float64()-0.5
+		}
+		dist := math.Max(math.Hypot(dx, dy), minDistance)
+		force := strength * mass / dist
+		disp[i].X += dx / dist * force
+		disp[i].Y += dy / dist * force
+	}
+	theta := f.theta()
+	if theta <= 0 {
+		for i := range pos {
+			for j := range pos {
+				if i != j {
+					push(i, pos[i].X-pos[j].X, pos[i].Y-pos[j].Y, 1)
+				}
+			}
+		}
+		return
+	}
+	root := newQuad(pos)
+	for i := range pos {
+		root.visit(i, pos, theta, push)
+	}
+}
+
+func fit(pos []svg.Pos, origin svg.Pos, dim svg.Dim) {
+	var (
+		x0, y0 = math.Inf(1), math.Inf(1)
+		x1, y1 = math.Inf(-1), math.Inf(-1)
+	)
+	for _, p := range pos {
+		x0, y0 = math.Min(x0, p.X), math.Min(y0, p.Y)
+		x1, y1 = math.Max(x1, p.X), math.Max(y1, p.Y)
+	}
+	var (
+		w, h  = x1 - x0, y1 - y0
+		scale = math.Min(dim.W/math.Max(w, minDistance), dim.H/math.Max(h, minDistance))
+		dx    = origin.X + (dim.W-w*scale)/2
+		dy    = origin.Y + (dim.H-h*scale)/2
+	)
+	for i := range pos {
+		pos[i].X = (pos[i].X-x0)*scale + dx
+		pos[i].Y = (pos[i].Y-y0)*scale + dy
+	}
+}
+
+func (f Force) render(d Drawing) svg.Element {
+	var (
+		canvas = svg.NewSVG()
+		defs   svg.Defs
+		edges  svg.Group
+		nodes  svg.Group
+		font   = f.Font.OrDefault()
+		radius = f.radius()
+	)
+	canvas.Dim = svg.NewDim(d.Width, d.Height)
+	if !f.Undirected {
+		marker := svg.NewMarker(arrowId, 6, 6)
+		marker.ViewBox.Dim = svg.NewDim(10, 10)
+		marker.RefX = 10
+		marker.RefY = 5
+
+		var arrow svg.Path
+		arrow.AbsMoveTo(svg.NewPos(0, 0))
+		arrow.AbsLineTo(svg.NewPos(10, 5))
+		arrow.AbsLineTo(svg.NewPos(0, 10))
+		arrow.ClosePath()
+		arrow.Fill = svg.NewFill("#999999")
+		marker.Append(arrow.AsElement())
+		defs.Append(marker.AsElement())
+		canvas.Append(defs.AsElement())
+	}
+	edges.Class = append(edges.Class, "edges")
+	nodes.Class = append(nodes.Class, "nodes")
+	for _, r := range d.Edges {
+		var (
+			src, dst = r.Points[0], r.Points[1]
+			dist     = math.Hypot(dst.X-src.X, dst.Y-src.Y)
+		)
+		if !f.Undirected && dist > 2*radius {
+			dst.X -= (dst.X - src.X) / dist * radius
+			dst.Y -= (dst.Y - src.Y) / dist * radius
+		}
+		line := svg.NewLine(src, dst)
+		line.Class = append(line.Class, "edge")
+		line.Stroke = svg.NewStroke("#999999", 1)
+		line.Data = []svg.Datum{
+			{Name: "source", Value: r.Source},
+			{Name: "target", Value: r.Target},
+		}
+		if !f.Undirected {
+			line.Markers.End = arrowId
+		}
+		edges.Append(line.AsElement())
+	}
+	for i, b := range d.Nodes {
+		var (
+			grp    svg.Group
+			circle svg.Circle
+			fill   = b.Color
+		)
+		if fill == "" {
+			fill = chart.Palette[i%len(chart.Palette)]
+		}
+		grp.Class = append(grp.Class, "node")
+		grp.Data = []svg.Datum{
+			{Name: "id", Value: b.Id},
+		}
+		circle.Pos = b.Center()
+		circle.Radius = radius
+		circle.Fill = svg.NewFill(fill)
+		circle.Stroke = svg.NewStroke("white", 1)
+		grp.Append(circle.AsElement())
+		if !f.OmitLabels {
+			text := svg.NewText(b.Text())
+			text.Font = font
+			text.Pos = b.Center().Adjust(radius+2, 0)
+			text.Baseline = "middle"
+			grp.Append(text.AsElement())
+		}
+		nodes.Append(grp.AsElement())
+	}
+	canvas.Append(edges.AsElement())
+	canvas.Append(nodes.AsElement())
+	return canvas.AsElement()
+}
+
+func (f Force) size() (float64, float64) {
+	width, height := f.Width, f.Height
+	if width <= 0 {
+		width = defaultForceWidth
+	}
+	if height <= 0 {
+		height = defaultForceHeight
+	}
+	return width, height
+}
+
+func (f Force) radius() float64 {
+	if f.Radius <= 0 {
+		return defaultNodeRadius
+	}
+	return f.Radius
+}
+
+func (f Force) repulsion() float64 {
+	if f.Repulsion <= 0 {
+		return 1
+	}
+	return f.Repulsion
+}
+
+func (f Force) attraction() float64 {
+	if f.Attraction <= 0 {
+		return 1
+	}
+	return f.Attraction
+}
+
+func (f Force) gravity() float64 {
+	if f.Gravity <= 0 {
+		return defaultGravity
+	}
+	return f.Gravity
+}
+
+func (f Force) theta() float64 {
+	switch {
+	case f.Theta < 0:
+		return 0
+	case f.Theta == 0:
+		return defaultTheta
+	default:
+		return f.Theta
+	}
+}
+
+const maxQuadDepth = 32
+
+type quad struct {
+	x0, y0   float64
+	x1, y1   float64
+	mass     float64
+	cx, cy   float64
+	body     int
+	children [4]*quad
+}
+
+func newQuad(pos []svg.Pos) *quad {
+	var (
+		x0, y0 = math.Inf(1), math.Inf(1)
+		x1, y1 = math.Inf(-1), math.Inf(-1)
+	)
+	for _, p := range pos {
+		x0, y0 = math.Min(x0, p.X), math.Min(y0, p.Y)
+		x1, y1 = math.Max(x1, p.X), math.Max(y1, p.Y)
+	}
+	size := math.Max(x1-x0, y1-y0) + 1
+	q := &quad{x0: x0, y0: y0, x1: x0 + size, y1: y0 + size, body: -1}
+	for i := range pos {
+		q.insert(i, pos, 0)
+	}
+	return q
+}
+
+func (q *quad) insert(i int, pos []svg.Pos, depth int) {
+	p := pos[i]
+	q.cx = (q.cx*q.mass + p.X) / (q.mass + 1)
+	q.cy = (q.cy*q.mass + p.Y) / (q.mass + 1)
+	q.mass++
+	if q.mass == 1 {
+		q.body = i
+		return
+	}
+	if depth >= maxQuadDepth {
+		return
+	}
+	if q.body >= 0 {
+		prev := q.body
+		q.body = -1
+		q.child(pos[prev]).insert(prev, pos, depth+1)
+	}
+	q.child(p).insert(i, pos, depth+1)
+}
+
+func (q *quad) child(p svg.Pos) *quad {
+	var (
+		mx  = (q.x0 + q.x1) / 2
+		my  = (q.y0 + q.y1) / 2
+		idx int
+		c   = quad{x0: q.x0, y0: q.y0, x1: mx, y1: my, body: -1}
+	)
+	if p.X >= mx {
+		idx |= 1
+		c.x0, c.x1 = mx, q.x1
+	}
+	if p.Y >= my {
+		idx |= 2
+		c.y0, c.y1 = my, q.y1
+	}
+	if q.children[idx] == nil {
+		q.children[idx] = &c
+	}
+	return q.children[idx]
+}
+
+func (q *quad) leaf() bool {
+	for _, c := range q.children {
+		if c != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *quad) visit(i int, pos []svg.Pos, theta float64, push func(int, float64, float64, float64)) {
+	if q == nil || q.mass == 0 || q.body == i && q.mass == 1 {
+		return
+	}
+	var (
+		dx   = pos[i].X - q.cx
+		dy   = pos[i].Y - q.cy
+		dist = math.Hypot(dx, dy)
+	)
+	if q.leaf() || (dist > 0 && (q.x1-q.x0)/dist < theta) {
+		mass := q.mass
+		if q.leaf() && q.body < 0 && dist == 0 {
+			mass--
+		}
+		if mass > 0 {
+			push(i, dx, dy, mass)
+		}
+		return
+	}
+	for _, c := range q.children {
+		c.visit(i, pos, theta, push)
+	}
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSyntax  = errors.New("syntax error")
+	ErrUnknown = errors.New("unknown node")
+)
+
+type Node struct {
+	Id    string
+	Label string
+	Color string
+}
+
+func (n Node) Text() string {
+	if n.Label != "" {
+		return n.Label
+	}
+	return n.Id
+}
+
+type Edge struct {
+	Source string
+	Target string
+	Label  string
+}
+
+type Graph struct {
+	Nodes      []Node
+	Edges      []Edge
+	Undirected bool
+
+	ids map[string]int
+}
+
+func (g *Graph) AddNode(n Node) {
+	if g.ids == nil || len(g.ids) != len(g.Nodes) {
+		g.ids = g.nodes()
+	}
+	i, ok := g.ids[n.Id]
+	if !ok {
+		g.ids[n.Id] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, n)
+		return
+	}
+	if n.Label != "" {
+		g.Nodes[i].Label = n.Label
+	}
+	if n.Color != "" {
+		g.Nodes[i].Color = n.Color
+	}
+}
+
+func (g *Graph) AddEdge(e Edge) {
+	g.AddNode(Node{Id: e.Source})
+	g.AddNode(Node{Id: e.Target})
+	g.Edges = append(g.Edges, e)
+}
+
+func (g Graph) nodes() map[string]int {
+	ids := make(map[string]int, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.Id] = i
+	}
+	return ids
+}
+
+func (g Graph) index() (map[string]int, error) {
+	index := g.nodes()
+	for _, e := range g.Edges {
+		if _, ok := index[e.Source]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknown, e.Source)
+		}
+		if _, ok := index[e.Target]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknown, e.Target)
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphAddNode(t *testing.T) {
+	g := Graph{
+		Nodes: []Node{{Id: "a"}},
+	}
+	g.AddEdge(Edge{Source: "a", Target: "b"})
+	g.AddEdge(Edge{Source: "b", Target: "c"})
+	g.AddNode(Node{Id: "b", Label: "B"})
+	g.AddNode(Node{Id: "c", Color: "red"})
+	g.Nodes = append(g.Nodes, Node{Id: "d"})
+	g.AddNode(Node{Id: "d", Label: "D"})
+
+	want := []Node{{Id: "a"}, {Id: "b", Label: "B"}, {Id: "c", Color: "red"}, {Id: "d", Label: "D"}}
+	if len(g.Nodes) != len(want) {
+		t.Fatalf("nodes mismatched: want %v, got %v", want, g.Nodes)
+	}
+	for i := range want {
+		if g.Nodes[i] != want[i] {
+			t.Errorf("node %d: want %v, got %v", i, want[i], g.Nodes[i])
+		}
+	}
+	if len(g.Edges) != 2 {
+		t.Errorf("want 2 edges, got %d", len(g.Edges))
+	}
+}
+
+func TestParseDOTDirected(t *testing.T) {
+	data := []struct {
+		Input      string
+		Undirected bool
+	}{
+		{Input: "digraph { a -> b }", Undirected: false},
+		{Input: "graph { a -- b }", Undirected: true},
+	}
+	for _, d := range data {
+		g, err := ParseDOT(strings.NewReader(d.Input))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if g.Undirected != d.Undirected {
+			t.Errorf("%s: want undirected %t, got %t", d.Input, d.Undirected, g.Undirected)
+		}
+		if len(g.Nodes) != 2 || len(g.Edges) != 1 {
+			t.Errorf("%s: want 2 nodes and 1 edge, got %d and %d", d.Input, len(g.Nodes), len(g.Edges))
+		}
+	}
+}
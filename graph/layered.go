@@ -0,0 +1,659 @@
+package graph
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/midbel/svg"
+)
+
+const (
+	defaultLayerSpacing = 48
+	defaultNodeSpacing  = 24
+	defaultIterations   = 24
+	defaultNodePadding  = 8
+	defaultMargin       = 16
+	arrowId             = "graph-arrow"
+)
+
+type Direction int
+
+const (
+	TopDown Direction = iota
+	LeftRight
+)
+
+type Box struct {
+	Node
+	X     float64
+	Y     float64
+	W     float64
+	H     float64
+	Layer int
+	Order int
+}
+
+func (b Box) Center() svg.Pos {
+	return svg.NewPos(b.X+b.W/2, b.Y+b.H/2)
+}
+
+type Route struct {
+	Edge
+	Points   []svg.Pos
+	Reversed bool
+}
+
+type Drawing struct {
+	Nodes  []Box
+	Edges  []Route
+	Width  float64
+	Height float64
+}
+
+type Layered struct {
+	Graph
+	Direction    Direction
+	Width        float64
+	Height       float64
+	LayerSpacing float64
+	NodeSpacing  float64
+	NodePadding  float64
+	Iterations   int
+	Font         svg.Font
+	Measure      func(string, svg.Font) float64
+}
+
+func (l Layered) Render(w io.Writer) error {
+	d, err := l.Layout()
+	if err != nil {
+		return err
+	}
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := l.render(d)
+	el.Render(ws)
+	return nil
+}
+
+func (l Layered) Element() svg.Element {
+	d, _ := l.Layout()
+	return l.render(d)
+}
+
+type vertex struct {
+	node  int
+	layer int
+	order int
+	width float64
+	size  float64
+	x     float64
+	up    []int
+	down  []int
+}
+
+func (v vertex) dummy() bool {
+	return v.node < 0
+}
+
+func (l Layered) Layout() (Drawing, error) {
+	var d Drawing
+	index, err := l.index()
+	if err != nil {
+		return d, err
+	}
+	var (
+		reversed = l.acyclic(index)
+		layers   = l.rank(index, reversed)
+		verts    = make([]vertex, len(l.Nodes))
+		chains   = make([][]int, len(l.Edges))
+	)
+	for i, n := range l.Nodes {
+		w, h := l.measure(n)
+		if l.Direction == LeftRight {
+			w, h = h, w
+		}
+		verts[i] = vertex{
+			node:  i,
+			layer: layers[i],
+			width: w,
+			size:  h,
+		}
+	}
+	for i, e := range l.Edges {
+		src, dst := index[e.Source], index[e.Target]
+		if src == dst {
+			continue
+		}
+		if reversed[i] {
+			src, dst = dst, src
+		}
+		chain := []int{src}
+		for y := verts[src].layer + 1; y < verts[dst].layer; y++ {
+			verts = append(verts, vertex{node: -1, layer: y})
+			chain = append(chain, len(verts)-1)
+		}
+		chain = append(chain, dst)
+		for j := 1; j < len(chain); j++ {
+			verts[chain[j-1]].down = append(verts[chain[j-1]].down, chain[j])
+			verts[chain[j]].up = append(verts[chain[j]].up, chain[j-1])
+		}
+		chains[i] = chain
+	}
+	rows := l.order(verts)
+	l.position(verts, rows)
+	return l.draw(verts, rows, chains, reversed), nil
+}
+
+func (l Layered) acyclic(index map[string]int) []bool {
+	var (
+		reversed = make([]bool, len(l.Edges))
+		state    = make([]int, len(l.Nodes))
+		out      = make([][]int, len(l.Nodes))
+		visit    func(int)
+	)
+	for i, e := range l.Edges {
+		out[index[e.Source]] = append(out[index[e.Source]], i)
+	}
+	visit = func(n int) {
+		state[n] = 1
+		for _, i := range out[n] {
+			next := index[l.Edges[i].Target]
+			if next == n {
+				continue
+			}
+			switch state[next] {
+			case 0:
+				visit(next)
+			case 1:
+				reversed[i] = true
+			}
+		}
+		state[n] = 2
+	}
+	for i := range l.Nodes {
+		if state[i] == 0 {
+			visit(i)
+		}
+	}
+	return reversed
+}
+
+func (l Layered) rank(index map[string]int, reversed []bool) []int {
+	var (
+		layers = make([]int, len(l.Nodes))
+		degree = make([]int, len(l.Nodes))
+		source = make([]bool, len(l.Nodes))
+		out    = make([][]int, len(l.Nodes))
+		queue  []int
+		order  []int
+	)
+	for i, e := range l.Edges {
+		src, dst := index[e.Source], index[e.Target]
+		if src == dst {
+			continue
+		}
+		if reversed[i] {
+			src, dst = dst, src
+		}
+		out[src] = append(out[src], dst)
+		degree[dst]++
+	}
+	for i := range l.Nodes {
+		if degree[i] == 0 {
+			source[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		order = append(order, n)
+		for _, next := range out[n] {
+			if layers[n]+1 > layers[next] {
+				layers[next] = layers[n] + 1
+			}
+			if degree[next]--; degree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		if len(out[n]) == 0 || !source[n] {
+			continue
+		}
+		lowest := math.MaxInt32
+		for _, next := range out[n] {
+			if layers[next] < lowest {
+				lowest = layers[next]
+			}
+		}
+		layers[n] = lowest - 1
+	}
+	return layers
+}
+
+func (l Layered) order(verts []vertex) [][]int {
+	var rows [][]int
+	for i := range verts {
+		for verts[i].layer >= len(rows) {
+			rows = append(rows, nil)
+		}
+		rows[verts[i].layer] = append(rows[verts[i].layer], i)
+	}
+	number := func() {
+		for _, row := range rows {
+			for j, v := range row {
+				verts[v].order = j
+			}
+		}
+	}
+	number()
+
+	var (
+		best  = copyRows(rows)
+		least = crossings(verts, rows)
+	)
+	for i := 0; i < l.iterations() && least > 0; i++ {
+		if i%2 == 0 {
+			for y := 1; y < len(rows); y++ {
+				sortRow(verts, rows[y], true)
+				number()
+			}
+		} else {
+			for y := len(rows) - 2; y >= 0; y-- {
+				sortRow(verts, rows[y], false)
+				number()
+			}
+		}
+		if c := crossings(verts, rows); c < least {
+			least = c
+			best = copyRows(rows)
+		}
+	}
+	rows = best
+	number()
+	return rows
+}
+
+func sortRow(verts []vertex, row []int, up bool) {
+	center := make(map[int]float64)
+	for _, v := range row {
+		list := verts[v].down
+		if up {
+			list = verts[v].up
+		}
+		if len(list) == 0 {
+			center[v] = float64(verts[v].order)
+			continue
+		}
+		var sum float64
+		for _, n := range list {
+			sum += float64(verts[n].order)
+		}
+		center[v] = sum / float64(len(list))
+	}
+	sort.SliceStable(row, func(i, j int) bool {
+		return center[row[i]] < center[row[j]]
+	})
+}
+
+func crossings(verts []vertex, rows [][]int) int {
+	var count int
+	for _, row := range rows {
+		var edges [][2]int
+		for _, v := range row {
+			for _, n := range verts[v].down {
+				edges = append(edges, [2]int{verts[v].order, verts[n].order})
+			}
+		}
+		for i := range edges {
+			for j := i + 1; j < len(edges); j++ {
+				a, b := edges[i], edges[j]
+				if (a[0]-b[0])*(a[1]-b[1]) < 0 {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+func copyRows(rows [][]int) [][]int {
+	tmp := make([][]int, len(rows))
+	for i := range rows {
+		tmp[i] = append([]int(nil), rows[i]...)
+	}
+	return tmp
+}
+
+func (l Layered) position(verts []vertex, rows [][]int) {
+	for _, row := range rows {
+		var x float64
+		for j, v := range row {
+			if j > 0 {
+				x += l.gap(verts[row[j-1]], verts[v])
+			}
+			verts[v].x = x
+		}
+	}
+	for i := 0; i < l.iterations(); i++ {
+		for y := 1; y < len(rows); y++ {
+			l.align(verts, rows[y], true)
+		}
+		for y := len(rows) - 2; y >= 0; y-- {
+			l.align(verts, rows[y], false)
+		}
+	}
+	min := math.Inf(1)
+	for _, v := range verts {
+		min = math.Min(min, v.x-v.width/2)
+	}
+	for i := range verts {
+		verts[i].x -= min
+	}
+}
+
+func (l Layered) align(verts []vertex, row []int, up bool) {
+	var (
+		want   = make([]float64, len(row))
+		weight = make([]float64, len(row))
+		offset = make([]float64, len(row))
+	)
+	for j, v := range row {
+		if j > 0 {
+			offset[j] = offset[j-1] + l.gap(verts[row[j-1]], verts[v])
+		}
+		list := verts[v].down
+		if up {
+			list = verts[v].up
+		}
+		want[j] = verts[v].x
+		weight[j] = 0.1
+		if len(list) > 0 {
+			var sum float64
+			for _, n := range list {
+				sum += verts[n].x
+			}
+			want[j] = sum / float64(len(list))
+			weight[j] = float64(len(list))
+			if verts[v].dummy() {
+				weight[j] *= 2
+			}
+		}
+		want[j] -= offset[j]
+	}
+	for j, x := range isotonic(want, weight) {
+		verts[row[j]].x = x + offset[j]
+	}
+}
+
+func isotonic(values, weights []float64) []float64 {
+	type block struct {
+		value  float64
+		weight float64
+		count  int
+	}
+	var stack []block
+	for i := range values {
+		b := block{value: values[i], weight: weights[i], count: 1}
+		for len(stack) > 0 && stack[len(stack)-1].value > b.value {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			w := top.weight + b.weight
+			b = block{
+				value:  (top.value*top.weight + b.value*b.weight) / w,
+				weight: w,
+				count:  top.count + b.count,
+			}
+		}
+		stack = append(stack, b)
+	}
+	var list []float64
+	for _, b := range stack {
+		for i := 0; i < b.count; i++ {
+			list = append(list, b.value)
+		}
+	}
+	return list
+}
+
+func (l Layered) gap(left, right vertex) float64 {
+	space := l.nodeSpacing()
+	if left.dummy() || right.dummy() {
+		space /= 2
+	}
+	return left.width/2 + space + right.width/2
+}
+
+func (l Layered) draw(verts []vertex, rows [][]int, chains [][]int, reversed []bool) Drawing {
+	var (
+		d      Drawing
+		tops   = make([]float64, len(rows))
+		sizes  = make([]float64, len(rows))
+		offset float64
+	)
+	for y, row := range rows {
+		for _, v := range row {
+			sizes[y] = math.Max(sizes[y], verts[v].size)
+		}
+		tops[y] = offset
+		offset += sizes[y] + l.layerSpacing()
+	}
+	project := func(x, y float64) svg.Pos {
+		if l.Direction == LeftRight {
+			return svg.NewPos(y, x)
+		}
+		return svg.NewPos(x, y)
+	}
+	for i, n := range l.Nodes {
+		var (
+			v   = verts[i]
+			mid = tops[v.layer] + sizes[v.layer]/2
+			pos = project(v.x-v.width/2, mid-v.size/2)
+			box = Box{
+				Node:  n,
+				X:     pos.X,
+				Y:     pos.Y,
+				W:     v.width,
+				H:     v.size,
+				Layer: v.layer,
+				Order: v.order,
+			}
+		)
+		if l.Direction == LeftRight {
+			box.W, box.H = box.H, box.W
+		}
+		d.Nodes = append(d.Nodes, box)
+		d.Width = math.Max(d.Width, box.X+box.W)
+		d.Height = math.Max(d.Height, box.Y+box.H)
+	}
+	for i, e := range l.Edges {
+		r := Route{
+			Edge:     e,
+			Reversed: reversed[i],
+		}
+		chain := chains[i]
+		for j, c := range chain {
+			var (
+				v   = verts[c]
+				mid = tops[v.layer] + sizes[v.layer]/2
+			)
+			switch j {
+			case 0:
+				mid += v.size / 2
+			case len(chain) - 1:
+				mid -= v.size / 2
+			}
+			r.Points = append(r.Points, project(v.x, mid))
+		}
+		if r.Reversed {
+			for a, b := 0, len(r.Points)-1; a < b; a, b = a+1, b-1 {
+				r.Points[a], r.Points[b] = r.Points[b], r.Points[a]
+			}
+		}
+		d.Edges = append(d.Edges, r)
+	}
+	return d
+}
+
+func (l Layered) render(d Drawing) svg.Element {
+	var (
+		canvas = svg.NewSVG()
+		defs   svg.Defs
+		edges  svg.Group
+		nodes  svg.Group
+		font   = l.Font.OrDefault()
+		width  = d.Width + 2*defaultMargin
+		height = d.Height + 2*defaultMargin
+	)
+	canvas.Dim = svg.NewDim(width, height)
+	if l.Width > 0 && l.Height > 0 {
+		canvas.Dim = svg.NewDim(l.Width, l.Height)
+	}
+	canvas.ViewBox.Pos = svg.NewPos(-defaultMargin, -defaultMargin)
+	canvas.ViewBox.Dim = svg.NewDim(width, height)
+	canvas.Ratio = svg.Ratio{Align: "xMidYMid", MeetOrSlice: "meet"}
+
+	if !l.Undirected {
+		marker := svg.NewMarker(arrowId, 8, 8)
+		marker.ViewBox.Dim = svg.NewDim(10, 10)
+		marker.RefX = 10
+		marker.RefY = 5
+
+		var arrow svg.Path
+		arrow.AbsMoveTo(svg.NewPos(0, 0))
+		arrow.AbsLineTo(svg.NewPos(10, 5))
+		arrow.AbsLineTo(svg.NewPos(0, 10))
+		arrow.ClosePath()
+		arrow.Fill = svg.NewFill("#555555")
+		marker.Append(arrow.AsElement())
+		defs.Append(marker.AsElement())
+		canvas.Append(defs.AsElement())
+	}
+
+	edges.Class = append(edges.Class, "edges")
+	nodes.Class = append(nodes.Class, "nodes")
+	for _, r := range d.Edges {
+		if len(r.Points) < 2 {
+			continue
+		}
+		path := l.curve(r.Points)
+		path.Class = append(path.Class, "edge")
+		path.Fill = svg.NewFill("none")
+		path.Stroke = svg.NewStroke("#555555", 1)
+		path.Data = []svg.Datum{
+			{Name: "source", Value: r.Source},
+			{Name: "target", Value: r.Target},
+		}
+		if !l.Undirected {
+			path.Markers.End = arrowId
+		}
+		edges.Append(path.AsElement())
+		if r.Label == "" {
+			continue
+		}
+		var (
+			mid  = r.Points[len(r.Points)/2]
+			prev = r.Points[len(r.Points)/2-1]
+			text = svg.NewText(r.Label)
+		)
+		text.Font = font
+		text.Font.Size = font.Size * 0.85
+		text.Pos = svg.NewPos((mid.X+prev.X)/2+defaultNodePadding/2, (mid.Y+prev.Y)/2)
+		text.Baseline = "middle"
+		text.Class = append(text.Class, "label")
+		edges.Append(text.AsElement())
+	}
+	for _, b := range d.Nodes {
+		var (
+			grp  svg.Group
+			rect svg.Rect
+			text = svg.NewText(b.Text())
+			fill = b.Color
+		)
+		if fill == "" {
+			fill = "#eef3fb"
+		}
+		grp.Class = append(grp.Class, "node")
+		grp.Data = []svg.Datum{
+			{Name: "id", Value: b.Id},
+			{Name: "layer", Value: b.Layer},
+		}
+		rect.Pos = svg.NewPos(b.X, b.Y)
+		rect.Dim = svg.NewDim(b.W, b.H)
+		rect.Fill = svg.NewFill(fill)
+		rect.Stroke = svg.NewStroke("#4a6fa5", 1)
+		text.Font = font
+		text.Pos = b.Center()
+		text.Anchor = svg.AlignMiddle
+		text.Baseline = "middle"
+		grp.Append(rect.AsElement())
+		grp.Append(text.AsElement())
+		nodes.Append(grp.AsElement())
+	}
+	canvas.Append(edges.AsElement())
+	canvas.Append(nodes.AsElement())
+	return canvas.AsElement()
+}
+
+func (l Layered) curve(points []svg.Pos) svg.Path {
+	var path svg.Path
+	path.AbsMoveTo(points[0])
+	for i := 1; i < len(points); i++ {
+		var (
+			a, b   = points[i-1], points[i]
+			c1, c2 svg.Pos
+		)
+		if l.Direction == LeftRight {
+			mid := (a.X + b.X) / 2
+			c1, c2 = svg.NewPos(mid, a.Y), svg.NewPos(mid, b.Y)
+		} else {
+			mid := (a.Y + b.Y) / 2
+			c1, c2 = svg.NewPos(a.X, mid), svg.NewPos(b.X, mid)
+		}
+		path.AbsCubicCurve(b, c1, c2)
+	}
+	return path
+}
+
+func (l Layered) measure(n Node) (float64, float64) {
+	var (
+		font  = l.Font.OrDefault()
+		pad   = l.NodePadding
+		width float64
+	)
+	if pad <= 0 {
+		pad = defaultNodePadding
+	}
+	if l.Measure != nil {
+		width = l.Measure(n.Text(), font)
+	} else {
+		width = svg.EstimateWidth(n.Text(), font)
+	}
+	return width + 2*pad, font.Size + 2*pad
+}
+
+func (l Layered) iterations() int {
+	if l.Iterations <= 0 {
+		return defaultIterations
+	}
+	return l.Iterations
+}
+
+func (l Layered) layerSpacing() float64 {
+	if l.LayerSpacing <= 0 {
+		return defaultLayerSpacing
+	}
+	return l.LayerSpacing
+}
+
+func (l Layered) nodeSpacing() float64 {
+	if l.NodeSpacing <= 0 {
+		return defaultNodeSpacing
+	}
+	return l.NodeSpacing
+}
@@ -0,0 +1,177 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLayeredRank(t *testing.T) {
+	data := []struct {
+		Name     string
+		Edges    []Edge
+		Layers   map[string]int
+		Reversed int
+	}{
+		{
+			Name:   "chain",
+			Edges:  []Edge{{Source: "a", Target: "b"}, {Source: "b", Target: "c"}},
+			Layers: map[string]int{"a": 0, "b": 1, "c": 2},
+		},
+		{
+			Name:   "shortcut",
+			Edges:  []Edge{{Source: "a", Target: "b"}, {Source: "b", Target: "c"}, {Source: "a", Target: "c"}},
+			Layers: map[string]int{"a": 0, "b": 1, "c": 2},
+		},
+		{
+			Name:   "late source",
+			Edges:  []Edge{{Source: "a", Target: "b"}, {Source: "b", Target: "c"}, {Source: "d", Target: "c"}},
+			Layers: map[string]int{"a": 0, "b": 1, "c": 2, "d": 1},
+		},
+		{
+			Name:     "cycle",
+			Edges:    []Edge{{Source: "a", Target: "b"}, {Source: "b", Target: "c"}, {Source: "c", Target: "a"}},
+			Layers:   map[string]int{"a": 0, "b": 1, "c": 2},
+			Reversed: 1,
+		},
+		{
+			Name:   "self loop",
+			Edges:  []Edge{{Source: "a", Target: "a"}, {Source: "a", Target: "b"}},
+			Layers: map[string]int{"a": 0, "b": 1},
+		},
+	}
+	for _, d := range data {
+		var l Layered
+		for _, e := range d.Edges {
+			l.AddEdge(e)
+		}
+		g, err := l.Layout()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		for _, b := range g.Nodes {
+			if want := d.Layers[b.Id]; b.Layer != want {
+				t.Errorf("%s: node %s: want layer %d, got %d", d.Name, b.Id, want, b.Layer)
+			}
+		}
+		var reversed int
+		for _, r := range g.Edges {
+			if r.Reversed {
+				reversed++
+			}
+		}
+		if reversed != d.Reversed {
+			t.Errorf("%s: want %d reversed edges, got %d", d.Name, d.Reversed, reversed)
+		}
+	}
+}
+
+func TestLayeredRoutes(t *testing.T) {
+	var l Layered
+	l.AddEdge(Edge{Source: "a", Target: "a"})
+	l.AddEdge(Edge{Source: "a", Target: "b"})
+	l.AddEdge(Edge{Source: "a", Target: "c"})
+	l.AddEdge(Edge{Source: "b", Target: "c"})
+	l.AddEdge(Edge{Source: "c", Target: "a"})
+
+	g, err := l.Layout()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []int{0, 2, 3, 2, 3}
+	for i, r := range g.Edges {
+		if len(r.Points) != want[i] {
+			t.Errorf("%s -> %s: want %d points, got %d", r.Source, r.Target, want[i], len(r.Points))
+		}
+	}
+	last := g.Edges[len(g.Edges)-1]
+	if !last.Reversed {
+		t.Fatalf("c -> a: edge should be reversed")
+	}
+	if a, c := g.Nodes[0].Center(), g.Nodes[2].Center(); last.Points[0].Y < last.Points[len(last.Points)-1].Y || a.Y > c.Y {
+		t.Errorf("c -> a: route should go from c up to a, got %v", last.Points)
+	}
+}
+
+func TestLayeredCrossings(t *testing.T) {
+	data := []struct {
+		Name  string
+		Nodes []string
+		Edges []Edge
+	}{
+		{
+			Name:  "swap",
+			Nodes: []string{"a", "b", "c", "d"},
+			Edges: []Edge{{Source: "a", Target: "d"}, {Source: "b", Target: "c"}},
+		},
+		{
+			Name:  "fan",
+			Nodes: []string{"a", "b", "c", "d", "e", "f"},
+			Edges: []Edge{
+				{Source: "a", Target: "f"},
+				{Source: "b", Target: "e"},
+				{Source: "c", Target: "d"},
+				{Source: "a", Target: "e"},
+			},
+		},
+		{
+			Name:  "long edge",
+			Nodes: []string{"a", "b", "c", "d", "e"},
+			Edges: []Edge{
+				{Source: "a", Target: "c"},
+				{Source: "c", Target: "e"},
+				{Source: "b", Target: "d"},
+				{Source: "a", Target: "d"},
+			},
+		},
+	}
+	for _, d := range data {
+		var l Layered
+		for _, n := range d.Nodes {
+			l.AddNode(Node{Id: n})
+		}
+		for _, e := range d.Edges {
+			l.AddEdge(e)
+		}
+		g, err := l.Layout()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		if n := countCrossings(g); n != 0 {
+			t.Errorf("%s: want no crossings, got %d", d.Name, n)
+		}
+	}
+}
+
+func TestLayeredUnknownNode(t *testing.T) {
+	l := Layered{
+		Graph: Graph{
+			Nodes: []Node{{Id: "a"}},
+			Edges: []Edge{{Source: "a", Target: "b"}},
+		},
+	}
+	if _, err := l.Layout(); !errors.Is(err, ErrUnknown) {
+		t.Errorf("want %s, got %v", ErrUnknown, err)
+	}
+}
+
+func countCrossings(d Drawing) int {
+	var count int
+	for i := range d.Edges {
+		for j := i + 1; j < len(d.Edges); j++ {
+			a, b := d.Edges[i].Points, d.Edges[j].Points
+			for x := 1; x < len(a); x++ {
+				for y := 1; y < len(b); y++ {
+					if a[x-1].Y != b[y-1].Y || a[x].Y != b[y].Y {
+						continue
+					}
+					if (a[x-1].X-b[y-1].X)*(a[x].X-b[y].X) < 0 {
+						count++
+					}
+				}
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,116 @@
+package hierarchy
+
+import (
+	"strings"
+)
+
+type Node struct {
+	Name     string
+	Value    float64
+	Color    string
+	Children []*Node
+}
+
+func (n *Node) Leaf() bool {
+	return len(n.Children) == 0
+}
+
+func (n *Node) Sum() float64 {
+	sum := n.Value
+	for _, c := range n.Children {
+		sum += c.Sum()
+	}
+	return sum
+}
+
+func (n *Node) Height() int {
+	var height int
+	for _, c := range n.Children {
+		if h := c.Height() + 1; h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+func (n *Node) Each(fn func(*Node, int)) {
+	n.each(fn, 0)
+}
+
+func (n *Node) each(fn func(*Node, int), depth int) {
+	fn(n, depth)
+	for _, c := range n.Children {
+		c.each(fn, depth+1)
+	}
+}
+
+type Cell struct {
+	Node   *Node
+	Parent int
+	Depth  int
+	X0     float64
+	Y0     float64
+	X1     float64
+	Y1     float64
+}
+
+func (c Cell) Width() float64 {
+	return c.X1 - c.X0
+}
+
+func (c Cell) Height() float64 {
+	return c.Y1 - c.Y0
+}
+
+func Ancestor(cells []Cell, i, depth int) int {
+	for i >= 0 && cells[i].Depth > depth {
+		i = cells[i].Parent
+	}
+	return i
+}
+
+func Path(cells []Cell, i int) string {
+	var list []string
+	for ; i >= 0; i = cells[i].Parent {
+		list = append(list, cells[i].Node.Name)
+	}
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return strings.Join(list, "/")
+}
+
+func Partition(root *Node, width, height float64) []Cell {
+	var (
+		cells []Cell
+		size  = height / float64(root.Height()+1)
+	)
+	var walk func(*Node, int, int, float64, float64)
+	walk = func(n *Node, parent, depth int, x0, x1 float64) {
+		cell := Cell{
+			Node:   n,
+			Parent: parent,
+			Depth:  depth,
+			X0:     x0,
+			X1:     x1,
+			Y0:     float64(depth) * size,
+			Y1:     float64(depth+1) * size,
+		}
+		cells = append(cells, cell)
+		var (
+			index = len(cells) - 1
+			total = n.Sum()
+			pos   = x0
+		)
+		if total <= 0 {
+			return
+		}
+		for _, c := range n.Children {
+			next := pos + (x1-x0)*c.Sum()/total
+			walk(c, index, depth+1, pos, next)
+			pos = next
+		}
+	}
+	walk(root, -1, 0, 0, width)
+	return cells
+}
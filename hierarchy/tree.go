@@ -0,0 +1,310 @@
+package hierarchy
+
+import (
+	"math"
+)
+
+type Point struct {
+	Node   *Node
+	Parent int
+	Depth  int
+	X      float64
+	Y      float64
+}
+
+func Tree(root *Node, width, height float64) []Point {
+	var (
+		fake = &tidy{}
+		top  = wrap(root, fake, 0)
+	)
+	fake.children = []*tidy{top}
+	top.postorder(firstWalk)
+	fake.m = -top.z
+	top.preorder(secondWalk)
+
+	var (
+		points = flatten(root)
+		left   = 0
+		right  = 0
+		bottom = 0
+		xs     = make([]float64, 0, len(points))
+	)
+	top.preorder(func(t *tidy) {
+		xs = append(xs, t.x)
+	})
+	for i := range points {
+		points[i].X = xs[i]
+		if points[i].X < points[left].X {
+			left = i
+		}
+		if points[i].X > points[right].X {
+			right = i
+		}
+		if points[i].Depth > points[bottom].Depth {
+			bottom = i
+		}
+	}
+	var (
+		s  = 1.0
+		ky = height
+	)
+	if left != right {
+		s = separation(points, left, right) / 2
+	}
+	var (
+		tx = s - points[left].X
+		kx = width / (points[right].X + s + tx)
+	)
+	if d := points[bottom].Depth; d > 0 {
+		ky = height / float64(d)
+	}
+	for i := range points {
+		points[i].X = (points[i].X + tx) * kx
+		points[i].Y = float64(points[i].Depth) * ky
+	}
+	return points
+}
+
+func Cluster(root *Node, width, height float64) []Point {
+	var (
+		points = flatten(root)
+		leaves []int
+		prev   = -1
+		x      float64
+	)
+	for i := range points {
+		if !points[i].Node.Leaf() {
+			continue
+		}
+		if prev >= 0 {
+			x += separation(points, i, prev)
+		}
+		points[i].X = x
+		prev = i
+		leaves = append(leaves, i)
+	}
+	var (
+		count = make([]float64, len(points))
+		level = make([]float64, len(points))
+	)
+	for i := len(points) - 1; i >= 0; i-- {
+		if count[i] > 0 {
+			points[i].X /= count[i]
+		}
+		if p := points[i].Parent; p >= 0 {
+			points[p].X += points[i].X
+			count[p]++
+			level[p] = math.Max(level[p], level[i]+1)
+		}
+	}
+	if len(leaves) == 0 {
+		return points
+	}
+	var (
+		first = leaves[0]
+		last  = leaves[len(leaves)-1]
+		x0    = points[first].X - separation(points, first, last)/2
+		x1    = points[last].X + separation(points, last, first)/2
+	)
+	for i := range points {
+		points[i].X = (points[i].X - x0) / (x1 - x0) * width
+		points[i].Y = height
+		if level[0] > 0 {
+			points[i].Y = (1 - level[i]/level[0]) * height
+		}
+	}
+	return points
+}
+
+func flatten(root *Node) []Point {
+	var (
+		points  []Point
+		parents []int
+	)
+	root.Each(func(n *Node, depth int) {
+		parents = parents[:depth]
+		parent := -1
+		if depth > 0 {
+			parent = parents[depth-1]
+		}
+		points = append(points, Point{
+			Node:   n,
+			Parent: parent,
+			Depth:  depth,
+		})
+		parents = append(parents, len(points)-1)
+	})
+	return points
+}
+
+func separation(points []Point, a, b int) float64 {
+	if points[a].Parent == points[b].Parent {
+		return 1
+	}
+	return 2
+}
+
+type tidy struct {
+	parent   *tidy
+	children []*tidy
+	index    int
+
+	ancestor *tidy
+	anchor   *tidy
+	thread   *tidy
+	z        float64
+	m        float64
+	c        float64
+	s        float64
+	x        float64
+}
+
+func wrap(n *Node, parent *tidy, index int) *tidy {
+	t := tidy{
+		parent: parent,
+		index:  index,
+	}
+	t.anchor = &t
+	for i, c := range n.Children {
+		t.children = append(t.children, wrap(c, &t, i))
+	}
+	return &t
+}
+
+func (t *tidy) preorder(fn func(*tidy)) {
+	fn(t)
+	for _, c := range t.children {
+		c.preorder(fn)
+	}
+}
+
+func (t *tidy) postorder(fn func(*tidy)) {
+	for _, c := range t.children {
+		c.postorder(fn)
+	}
+	fn(t)
+}
+
+func (t *tidy) left() *tidy {
+	if len(t.children) > 0 {
+		return t.children[0]
+	}
+	return t.thread
+}
+
+func (t *tidy) right() *tidy {
+	if len(t.children) > 0 {
+		return t.children[len(t.children)-1]
+	}
+	return t.thread
+}
+
+func (t *tidy) separation(other *tidy) float64 {
+	if t.parent == other.parent {
+		return 1
+	}
+	return 2
+}
+
+func firstWalk(v *tidy) {
+	var (
+		siblings = v.parent.children
+		w        *tidy
+	)
+	if v.index > 0 {
+		w = siblings[v.index-1]
+	}
+	if len(v.children) > 0 {
+		executeShifts(v)
+		mid := (v.children[0].z + v.children[len(v.children)-1].z) / 2
+		if w != nil {
+			v.z = w.z + v.separation(w)
+			v.m = v.z - mid
+		} else {
+			v.z = mid
+		}
+	} else if w != nil {
+		v.z = w.z + v.separation(w)
+	}
+	ancestor := v.parent.ancestor
+	if ancestor == nil {
+		ancestor = siblings[0]
+	}
+	v.parent.ancestor = apportion(v, w, ancestor)
+}
+
+func secondWalk(v *tidy) {
+	v.x = v.z + v.parent.m
+	v.m += v.parent.m
+}
+
+func executeShifts(v *tidy) {
+	var shift, change float64
+	for i := len(v.children) - 1; i >= 0; i-- {
+		w := v.children[i]
+		w.z += shift
+		w.m += shift
+		change += w.c
+		shift += w.s + change
+	}
+}
+
+func moveSubtree(wm, wp *tidy, shift float64) {
+	change := shift / float64(wp.index-wm.index)
+	wp.c -= change
+	wp.s += shift
+	wm.c += change
+	wp.z += shift
+	wp.m += shift
+}
+
+func nextAncestor(vim, v, ancestor *tidy) *tidy {
+	if vim.anchor.parent == v.parent {
+		return vim.anchor
+	}
+	return ancestor
+}
+
+func apportion(v, w, ancestor *tidy) *tidy {
+	if w == nil {
+		return ancestor
+	}
+	var (
+		vip = v
+		vop = v
+		vim = w
+		vom = v.parent.children[0]
+		sip = vip.m
+		sop = vop.m
+		sim = vim.m
+		som = vom.m
+	)
+	for {
+		vim, vip = vim.right(), vip.left()
+		if vim == nil || vip == nil {
+			break
+		}
+		vom = vom.left()
+		vop = vop.right()
+		vop.anchor = v
+		if shift := vim.z + sim - vip.z - sip + vim.separation(vip); shift > 0 {
+			moveSubtree(nextAncestor(vim, v, ancestor), v, shift)
+			sip += shift
+			sop += shift
+		}
+		sim += vim.m
+		sip += vip.m
+		som += vom.m
+		sop += vop.m
+	}
+	if vim != nil && vop.right() == nil {
+		vop.thread = vim
+		vop.m += sim - sop
+	}
+	if vip != nil && vom.left() == nil {
+		vom.thread = vip
+		vom.m += sip - som
+		ancestor = v
+	}
+	return ancestor
+}
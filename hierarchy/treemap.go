@@ -0,0 +1,275 @@
+package hierarchy
+
+import (
+	"math"
+	"sort"
+)
+
+var golden = (1 + math.Sqrt(5)) / 2
+
+type Tiling int
+
+const (
+	Squarify Tiling = iota
+	SliceDice
+	Slice
+	Dice
+	Binary
+)
+
+type Treemap struct {
+	Tile  Tiling
+	Ratio float64
+	Inner float64
+	Outer float64
+	Top   float64
+}
+
+func (t Treemap) Layout(root *Node, width, height float64) []Cell {
+	var (
+		cells []Cell
+		walk  func(*Node, int, int, float64, box)
+	)
+	walk = func(n *Node, parent, depth int, pad float64, b box) {
+		b = b.shrink(pad, pad, pad, pad)
+		cells = append(cells, Cell{
+			Node:   n,
+			Parent: parent,
+			Depth:  depth,
+			X0:     b.x0,
+			Y0:     b.y0,
+			X1:     b.x1,
+			Y1:     b.y1,
+		})
+		if n.Leaf() {
+			return
+		}
+		var (
+			index    = len(cells) - 1
+			children = t.order(n.Children)
+			values   = make([]float64, len(children))
+			inner    = t.Inner / 2
+		)
+		for i, c := range children {
+			values[i] = c.Sum()
+		}
+		b = b.shrink(t.Outer-inner, t.Top-inner, t.Outer-inner, t.Outer-inner)
+		for i, r := range t.tile(values, depth, b) {
+			walk(children[i], index, depth+1, inner, r)
+		}
+	}
+	walk(root, -1, 0, 0, box{x1: width, y1: height})
+	return cells
+}
+
+func (t Treemap) order(list []*Node) []*Node {
+	if t.Tile != Squarify && t.Tile != Binary {
+		return list
+	}
+	tmp := make([]*Node, len(list))
+	copy(tmp, list)
+	sort.SliceStable(tmp, func(i, j int) bool {
+		return tmp[i].Sum() > tmp[j].Sum()
+	})
+	return tmp
+}
+
+func (t Treemap) tile(values []float64, depth int, b box) []box {
+	switch t.Tile {
+	case SliceDice:
+		if depth%2 == 1 {
+			return slice(values, b)
+		}
+		return dice(values, b)
+	case Slice:
+		return slice(values, b)
+	case Dice:
+		return dice(values, b)
+	case Binary:
+		return binary(values, b)
+	default:
+		ratio := t.Ratio
+		if ratio <= 1 {
+			ratio = golden
+		}
+		return squarify(values, ratio, b)
+	}
+}
+
+type box struct {
+	x0, y0 float64
+	x1, y1 float64
+}
+
+func (b box) shrink(left, top, right, bottom float64) box {
+	b.x0 += left
+	b.y0 += top
+	b.x1 -= right
+	b.y1 -= bottom
+	if b.x1 < b.x0 {
+		b.x0 = (b.x0 + b.x1) / 2
+		b.x1 = b.x0
+	}
+	if b.y1 < b.y0 {
+		b.y0 = (b.y0 + b.y1) / 2
+		b.y1 = b.y0
+	}
+	return b
+}
+
+func total(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+func dice(values []float64, b box) []box {
+	var (
+		list = make([]box, len(values))
+		sum  = total(values)
+		x    = b.x0
+		k    float64
+	)
+	if sum > 0 {
+		k = (b.x1 - b.x0) / sum
+	}
+	for i, v := range values {
+		list[i] = box{x0: x, y0: b.y0, x1: x + v*k, y1: b.y1}
+		x = list[i].x1
+	}
+	return list
+}
+
+func slice(values []float64, b box) []box {
+	var (
+		list = make([]box, len(values))
+		sum  = total(values)
+		y    = b.y0
+		k    float64
+	)
+	if sum > 0 {
+		k = (b.y1 - b.y0) / sum
+	}
+	for i, v := range values {
+		list[i] = box{x0: b.x0, y0: y, x1: b.x1, y1: y + v*k}
+		y = list[i].y1
+	}
+	return list
+}
+
+func squarify(values []float64, ratio float64, b box) []box {
+	var (
+		list  = make([]box, 0, len(values))
+		value = total(values)
+		n     = len(values)
+	)
+	for i0, i1 := 0, 0; i0 < n; i0 = i1 {
+		var (
+			dx  = b.x1 - b.x0
+			dy  = b.y1 - b.y0
+			sum float64
+		)
+		for i1 < n {
+			sum = values[i1]
+			i1++
+			if sum > 0 {
+				break
+			}
+		}
+		var (
+			lo, hi = sum, sum
+			alpha  = math.Max(dy/dx, dx/dy) / (value * ratio)
+			beta   = sum * sum * alpha
+			worst  = math.Max(hi/beta, beta/lo)
+		)
+		for ; i1 < n; i1++ {
+			v := values[i1]
+			sum += v
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+			beta = sum * sum * alpha
+			next := math.Max(hi/beta, beta/lo)
+			if next > worst {
+				sum -= v
+				break
+			}
+			worst = next
+		}
+		row := b
+		if dx < dy {
+			if value > 0 {
+				b.y0 += dy * sum / value
+			}
+			row.y1 = b.y0
+			list = append(list, dice(values[i0:i1], row)...)
+		} else {
+			if value > 0 {
+				b.x0 += dx * sum / value
+			}
+			row.x1 = b.x0
+			list = append(list, slice(values[i0:i1], row)...)
+		}
+		value -= sum
+	}
+	return list
+}
+
+func binary(values []float64, b box) []box {
+	var (
+		list = make([]box, len(values))
+		sums = make([]float64, len(values)+1)
+	)
+	for i, v := range values {
+		sums[i+1] = sums[i] + v
+	}
+	var split func(int, int, float64, box)
+	split = func(i, j int, value float64, b box) {
+		if i >= j-1 {
+			list[i] = b
+			return
+		}
+		var (
+			offset = sums[i]
+			target = value/2 + offset
+			k      = i + 1
+			hi     = j - 1
+		)
+		for k < hi {
+			mid := (k + hi) / 2
+			if sums[mid] < target {
+				k = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if target-sums[k-1] < sums[k]-target && i+1 < k {
+			k--
+		}
+		var (
+			left  = sums[k] - offset
+			right = value - left
+			a, c  = b, b
+		)
+		if b.x1-b.x0 > b.y1-b.y0 {
+			xk := b.x1
+			if value > 0 {
+				xk = (b.x0*right + b.x1*left) / value
+			}
+			a.x1, c.x0 = xk, xk
+		} else {
+			yk := b.y1
+			if value > 0 {
+				yk = (b.y0*right + b.y1*left) / value
+			}
+			a.y1, c.y0 = yk, yk
+		}
+		split(i, k, left, a)
+		split(k, j, right, c)
+	}
+	if len(values) > 0 {
+		split(0, len(values), sums[len(values)], b)
+	}
+	return list
+}
@@ -0,0 +1,81 @@
+package svg
+
+import (
+	"strconv"
+)
+
+type Registry struct {
+	taken map[string]struct{}
+	seq   map[string]int
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		taken: make(map[string]struct{}),
+		seq:   make(map[string]int),
+	}
+}
+
+func (r *Registry) Generate(prefix string) string {
+	if prefix == "" {
+		prefix = "id"
+	}
+	for {
+		r.seq[prefix]++
+		id := prefix + "-" + strconv.Itoa(r.seq[prefix])
+		if !r.Used(id) {
+			r.mark(id)
+			return id
+		}
+	}
+}
+
+func (r *Registry) Register(id string) string {
+	if !r.Used(id) {
+		r.mark(id)
+		return id
+	}
+	for i := 2; ; i++ {
+		next := id + "-" + strconv.Itoa(i)
+		if !r.Used(next) {
+			r.mark(next)
+			return next
+		}
+	}
+}
+
+func (r *Registry) Used(id string) bool {
+	_, ok := r.taken[id]
+	return ok
+}
+
+func (r *Registry) mark(id string) {
+	r.taken[id] = struct{}{}
+}
+
+type identified interface {
+	identity() *node
+}
+
+func (n *node) identity() *node {
+	return n
+}
+
+func resourceKind(e Element) string {
+	switch e.(type) {
+	case *Linear:
+		return "linear"
+	case *Radial:
+		return "radial"
+	case *ClipPath:
+		return "clip"
+	case *Mask:
+		return "mask"
+	case *Marker:
+		return "marker"
+	case *Pattern:
+		return "pattern"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,45 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	for _, want := range []string{"grad", "grad-2", "grad-3"} {
+		if got := reg.Register("grad"); got != want {
+			t.Errorf("register: want %s, got %s", want, got)
+		}
+	}
+	reg.Register("clip-2")
+	for _, want := range []string{"clip-1", "clip-3"} {
+		if got := reg.Generate("clip"); got != want {
+			t.Errorf("generate: want %s, got %s", want, got)
+		}
+	}
+	if got := reg.Register("clip-1"); got != "clip-1-2" {
+		t.Errorf("register generated id: want clip-1-2, got %s", got)
+	}
+}
+
+func TestRenderReproducible(t *testing.T) {
+	build := func() *SVG {
+		root := NewSVG()
+		root.Append(testChart("red"))
+		root.Append(testChart("blue"))
+		return &root
+	}
+	var (
+		first  = renderString(build())
+		second = renderString(build())
+	)
+	if first != second {
+		t.Errorf("same input renders differently: %s and %s", first, second)
+	}
+	for _, want := range []string{`id="grad"`, `id="grad-2"`, `fill="url(#grad-2)"`} {
+		if !strings.Contains(first, want) {
+			t.Errorf("%s not found in %s", want, first)
+		}
+	}
+}
@@ -49,6 +49,9 @@ func (n *node) Attributes() []string {
 	if n.Rendering != "" {
 		attrs = append(attrs, appendString("shape-rendering", n.Rendering))
 	}
+	if style := inlineStyle(n.Styles); style != "" {
+		attrs = append(attrs, appendString("style", style))
+	}
 	if len(n.Data) > 0 {
 		for i := range n.Data {
 			attrs = append(attrs, n.Data[i].Attributes()...)
@@ -63,6 +66,9 @@ func (n *node) render(w Writer, name string, list List, attrs ...Attribute) {
 	for _, a := range attrs {
 		as = append(as, a.Attributes()...)
 	}
+	if d, ok := w.(*document); ok {
+		d.current = n
+	}
 	writeElement(w, name, as, func() {
 		writeTitle(w, n.Title)
 		writeDesc(w, n.Desc)
@@ -78,16 +84,11 @@ func writeElement(w Writer, name string, attrs []string, inner func()) {
 	}
 }
 
-func writeData(w Writer, str, prefix string) {
+func cdata(str, prefix string) string {
 	if str == "" {
-		return
+		return ""
 	}
-	w.WriteString(prefix)
-	w.WriteString("<![CDATA[\n")
-	w.WriteString(str)
-	w.WriteString("\n")
-	w.WriteString(prefix)
-	w.WriteString("]]>")
+	return prefix + "<![CDATA[\n" + str + "\n" + prefix + "]]>"
 }
 
 func writeTitle(w Writer, str string) {
@@ -111,6 +112,10 @@ func writeString(w Writer, name, str string) {
 }
 
 func writeOpenElement(w Writer, name string, closed bool, attrs []string) {
+	d, ok := w.(*document)
+	if ok {
+		attrs = d.open(name, attrs)
+	}
 	w.WriteByte(langle)
 	w.WriteString(name)
 	for i := range attrs {
@@ -122,6 +127,9 @@ func writeOpenElement(w Writer, name string, closed bool, attrs []string) {
 		w.WriteByte(slash)
 	}
 	w.WriteByte(rangle)
+	if ok && !closed {
+		d.opened(name)
+	}
 }
 
 func writeCloseElement(w Writer, name string) {
@@ -129,4 +137,7 @@ func writeCloseElement(w Writer, name string) {
 	w.WriteByte(slash)
 	w.WriteString(name)
 	w.WriteByte(rangle)
+	if d, ok := w.(*document); ok {
+		d.closed(name)
+	}
 }
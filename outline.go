@@ -0,0 +1,368 @@
+package svg
+
+import (
+	"math"
+
+	"github.com/midbel/svg/font"
+)
+
+const curveSteps = 16
+
+type glyphRun struct {
+	Pos
+	Str string
+}
+
+func (t *Text) Outline(face *font.Face) (Path, error) {
+	var (
+		path  Path
+		size  = fontSize(t.Font)
+		scale = size / face.UnitsPerEm()
+		pen   = t.Pos.Adjust(t.Shift.X, t.Shift.Y)
+		runs  [][]glyphRun
+	)
+	path.node = t.node
+	path.Fill = t.Fill
+	path.Stroke = t.Stroke
+	path.Transform = t.Transform
+
+	runs = append(runs, nil)
+	for _, e := range t.List.List {
+		switch e := e.(type) {
+		case Literal:
+			runs[len(runs)-1] = append(runs[len(runs)-1], glyphRun{Pos: pen, Str: string(e)})
+			pen.X += face.Measure(string(e), size)
+		case *TextSpan:
+			if !e.Pos.IsZero() {
+				pen = e.Pos
+				runs = append(runs, nil)
+			}
+			pen = pen.Adjust(e.Shift.X, e.Shift.Y)
+			runs[len(runs)-1] = append(runs[len(runs)-1], glyphRun{Pos: pen, Str: e.Literal})
+			pen.X += face.Measure(e.Literal, size)
+		}
+	}
+	for _, chunk := range runs {
+		var width float64
+		for _, r := range chunk {
+			width += face.Measure(r.Str, size)
+		}
+		offset := anchorOffset(t.Anchor, width)
+		for _, r := range chunk {
+			x := r.X + offset
+			err := eachGlyph(face, r.Str, func(g font.Index, kern, adv float64) error {
+				x += kern * scale
+				segs, err := face.Glyph(g)
+				if err != nil {
+					return err
+				}
+				appendGlyph(&path, segs, func(p font.Point) Pos {
+					return NewPos(x+p.X*scale, r.Y-p.Y*scale)
+				})
+				x += adv * scale
+				return nil
+			})
+			if err != nil {
+				return path, err
+			}
+		}
+	}
+	return path, nil
+}
+
+func (t *TextPath) Outline(face *font.Face, ref Path) (Path, error) {
+	var (
+		path  Path
+		size  = fontSize(t.Font)
+		scale = size / face.UnitsPerEm()
+		width = face.Measure(t.Literal, size)
+		track = ref.flatten()
+		dist  = t.Offset + t.Shift.X + anchorOffset(t.Anchor, width)
+	)
+	path.node = t.node
+	path.Fill = t.Fill
+	path.Stroke = t.Stroke
+	path.Transform = t.Transform
+
+	err := eachGlyph(face, t.Literal, func(g font.Index, kern, adv float64) error {
+		dist += kern * scale
+		var (
+			half = adv * scale / 2
+			mid  = dist + half
+		)
+		dist += adv * scale
+		pos, angle, ok := track.at(mid)
+		if !ok {
+			return nil
+		}
+		segs, err := face.Glyph(g)
+		if err != nil {
+			return err
+		}
+		sin, cos := math.Sincos(angle)
+		appendGlyph(&path, segs, func(p font.Point) Pos {
+			var (
+				x = p.X*scale - half
+				y = t.Shift.Y - p.Y*scale
+			)
+			return NewPos(pos.X+x*cos-y*sin, pos.Y+x*sin+y*cos)
+		})
+		return nil
+	})
+	return path, err
+}
+
+func eachGlyph(face *font.Face, str string, fn func(font.Index, float64, float64) error) error {
+	var prev font.Index
+	for i, r := range str {
+		var (
+			g    = face.Index(r)
+			kern float64
+		)
+		if i > 0 {
+			kern = face.Kern(prev, g)
+		}
+		if err := fn(g, kern, face.Advance(g)); err != nil {
+			return err
+		}
+		prev = g
+	}
+	return nil
+}
+
+func appendGlyph(path *Path, segs []font.Segment, at func(font.Point) Pos) {
+	for _, s := range segs {
+		switch s.Op {
+		case font.MoveTo:
+			path.AbsMoveTo(at(s.Args[0]))
+		case font.LineTo:
+			path.AbsLineTo(at(s.Args[0]))
+		case font.QuadTo:
+			path.AbsQuadraticCurve(at(s.Args[1]), at(s.Args[0]))
+		case font.Close:
+			path.ClosePath()
+		}
+	}
+}
+
+func anchorOffset(anchor string, width float64) float64 {
+	switch anchor {
+	case AlignMiddle:
+		return -width / 2
+	case AlignEnd:
+		return -width
+	default:
+		return 0
+	}
+}
+
+type segment struct {
+	Starts Pos
+	Ends   Pos
+	Length float64
+}
+
+type track []segment
+
+func (t track) at(dist float64) (Pos, float64, bool) {
+	if dist < 0 {
+		return Pos{}, 0, false
+	}
+	for _, s := range t {
+		if dist > s.Length {
+			dist -= s.Length
+			continue
+		}
+		var (
+			ratio = 0.0
+			dx    = s.Ends.X - s.Starts.X
+			dy    = s.Ends.Y - s.Starts.Y
+		)
+		if s.Length > 0 {
+			ratio = dist / s.Length
+		}
+		pos := NewPos(s.Starts.X+dx*ratio, s.Starts.Y+dy*ratio)
+		return pos, math.Atan2(dy, dx), true
+	}
+	return Pos{}, 0, false
+}
+
+func (p *Path) flatten() track {
+	var (
+		list  track
+		curr  Pos
+		start Pos
+		ctrl  Pos
+		last  string
+	)
+	lineTo := func(pos Pos) {
+		s := segment{
+			Starts: curr,
+			Ends:   pos,
+			Length: math.Hypot(pos.X-curr.X, pos.Y-curr.Y),
+		}
+		list = append(list, s)
+		curr = pos
+	}
+	curveTo := func(fn func(float64) Pos) {
+		for i := 1; i <= curveSteps; i++ {
+			lineTo(fn(float64(i) / curveSteps))
+		}
+	}
+	for _, c := range p.commands {
+		var (
+			rel  = c.cmd != "" && c.cmd[0] >= 'a' && c.cmd[0] <= 'z'
+			args = c.values
+			next Pos
+		)
+		point := func(i int) Pos {
+			if i >= len(args) || len(args[i]) < 2 {
+				return curr
+			}
+			pos := NewPos(args[i][0], args[i][1])
+			if rel {
+				pos = pos.Adjust(curr.X, curr.Y)
+			}
+			return pos
+		}
+		switch c.cmd {
+		case cmdMoveToAbs, cmdMoveToRel:
+			curr = point(0)
+			start = curr
+		case cmdLineToAbs, cmdLineToRel:
+			lineTo(point(0))
+		case cmdHorizontalAbs, cmdHorizontalRel:
+			next = curr
+			if rel {
+				next.X += args[0][0]
+			} else {
+				next.X = args[0][0]
+			}
+			lineTo(next)
+		case cmdVerticalAbs, cmdVerticalRel:
+			next = curr
+			if rel {
+				next.Y += args[0][0]
+			} else {
+				next.Y = args[0][0]
+			}
+			lineTo(next)
+		case cmdClosePath:
+			lineTo(start)
+		case cmdCubicAbs, cmdCubicRel, cmdCubicSimpleAbs, cmdCubicSimpleRel:
+			var c1, c2 Pos
+			if c.cmd == cmdCubicAbs || c.cmd == cmdCubicRel {
+				c1, c2, next = point(0), point(1), point(2)
+			} else {
+				c1 = curr
+				if last == cmdCubicAbs || last == cmdCubicRel || last == cmdCubicSimpleAbs || last == cmdCubicSimpleRel {
+					c1 = NewPos(2*curr.X-ctrl.X, 2*curr.Y-ctrl.Y)
+				}
+				c2, next = point(0), point(1)
+			}
+			p0 := curr
+			curveTo(func(t float64) Pos {
+				return cubicAt(p0, c1, c2, next, t)
+			})
+			ctrl = c2
+		case cmdQuadraticAbs, cmdQuadraticRel, cmdQuadraticSimpleAbs, cmdQuadraticSimpleRel:
+			var c1 Pos
+			if c.cmd == cmdQuadraticAbs || c.cmd == cmdQuadraticRel {
+				c1, next = point(0), point(1)
+			} else {
+				c1 = curr
+				if last == cmdQuadraticAbs || last == cmdQuadraticRel || last == cmdQuadraticSimpleAbs || last == cmdQuadraticSimpleRel {
+					c1 = NewPos(2*curr.X-ctrl.X, 2*curr.Y-ctrl.Y)
+				}
+				next = point(0)
+			}
+			p0 := curr
+			curveTo(func(t float64) Pos {
+				return quadAt(p0, c1, next, t)
+			})
+			ctrl = c1
+		case cmdArcAbs, cmdArcRel:
+			if len(args) == 0 || len(args[0]) < 7 {
+				break
+			}
+			a := args[0]
+			next = NewPos(a[5], a[6])
+			if rel {
+				next = next.Adjust(curr.X, curr.Y)
+			}
+			fn := arcAt(curr, next, a[0], a[1], a[2], a[3] != 0, a[4] != 0)
+			if fn == nil {
+				lineTo(next)
+				break
+			}
+			curveTo(fn)
+		}
+		last = c.cmd
+	}
+	return list
+}
+
+func cubicAt(p0, p1, p2, p3 Pos, t float64) Pos {
+	var (
+		u = 1 - t
+		a = u * u * u
+		b = 3 * u * u * t
+		c = 3 * u * t * t
+		d = t * t * t
+	)
+	return NewPos(a*p0.X+b*p1.X+c*p2.X+d*p3.X, a*p0.Y+b*p1.Y+c*p2.Y+d*p3.Y)
+}
+
+func quadAt(p0, p1, p2 Pos, t float64) Pos {
+	var (
+		u = 1 - t
+		a = u * u
+		b = 2 * u * t
+		c = t * t
+	)
+	return NewPos(a*p0.X+b*p1.X+c*p2.X, a*p0.Y+b*p1.Y+c*p2.Y)
+}
+
+func arcAt(from, to Pos, rx, ry, rot float64, large, sweep bool) func(float64) Pos {
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || from == to {
+		return nil
+	}
+	var (
+		phi      = rot * math.Pi / 180
+		sin, cos = math.Sincos(phi)
+		dx       = (from.X - to.X) / 2
+		dy       = (from.Y - to.Y) / 2
+		x1       = cos*dx + sin*dy
+		y1       = -sin*dx + cos*dy
+	)
+	if lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+	var (
+		num  = rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+		den  = rx*rx*y1*y1 + ry*ry*x1*x1
+		coef = math.Sqrt(math.Max(num, 0) / den)
+	)
+	if large == sweep {
+		coef = -coef
+	}
+	var (
+		cx1   = coef * rx * y1 / ry
+		cy1   = -coef * ry * x1 / rx
+		cx    = cos*cx1 - sin*cy1 + (from.X+to.X)/2
+		cy    = sin*cx1 + cos*cy1 + (from.Y+to.Y)/2
+		theta = math.Atan2((y1-cy1)/ry, (x1-cx1)/rx)
+		delta = math.Atan2((-y1-cy1)/ry, (-x1-cx1)/rx) - theta
+	)
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+	return func(t float64) Pos {
+		s, c := math.Sincos(theta + delta*t)
+		return NewPos(cx+rx*c*cos-ry*s*sin, cy+rx*c*sin+ry*s*cos)
+	}
+}
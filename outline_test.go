@@ -0,0 +1,121 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/midbel/svg/font"
+)
+
+func TestTextOutline(t *testing.T) {
+	face := loadFace(t)
+	span := func(str string, pos, shift Pos) Element {
+		return &TextSpan{Literal: str, Pos: pos, Shift: shift}
+	}
+	data := []struct {
+		Name  string
+		Text  Text
+		Spans []Element
+		Want  string
+	}{
+		{
+			Name: "kerning",
+			Text: NewText("AV"),
+			Want: "M 11 20 L 15 20 L 15 13 L 11 13 L 11 20 Z M 15 13 L 17.5 20 L 20 13 L 15 13 Z",
+		},
+		{
+			Name: "quadratic",
+			Text: NewText("O"),
+			Want: "M 12.5 20 Q 15 20, 15 16.5 Q 15 13, 12.5 13 Q 10 13, 10 16.5 Q 10 20, 12.5 20 Z",
+		},
+		{
+			Name: "space",
+			Text: NewText("A A"),
+			Want: "M 11 20 L 15 20 L 15 13 L 11 13 L 11 20 Z M 19.5 20 L 23.5 20 L 23.5 13 L 19.5 13 L 19.5 20 Z",
+		},
+		{
+			Name:  "span",
+			Spans: []Element{Literal("A"), span("V", Pos{}, Pos{})},
+			Want:  "M 11 20 L 15 20 L 15 13 L 11 13 L 11 20 Z M 16 13 L 18.5 20 L 21 13 L 16 13 Z",
+		},
+		{
+			Name:  "shift",
+			Spans: []Element{Literal("A"), span("V", Pos{}, NewPos(2, 1))},
+			Want:  "M 11 20 L 15 20 L 15 13 L 11 13 L 11 20 Z M 18 14 L 20.5 21 L 23 14 L 18 14 Z",
+		},
+		{
+			Name:  "positioned",
+			Spans: []Element{Literal("A"), span("V", NewPos(40, 50), NewPos(0, 5))},
+			Want:  "M 11 20 L 15 20 L 15 13 L 11 13 L 11 20 Z M 40 48 L 42.5 55 L 45 48 L 40 48 Z",
+		},
+	}
+	for _, d := range data {
+		text := d.Text
+		for _, e := range d.Spans {
+			text.Append(e)
+		}
+		text.Pos = NewPos(10, 20)
+		text.Font = NewFont(10)
+		path, err := text.Outline(face)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Name, err)
+			continue
+		}
+		if got := pathData(&path); got != d.Want {
+			t.Errorf("%s: outline mismatched:\nwant %s\ngot  %s", d.Name, d.Want, got)
+		}
+	}
+}
+
+func TestTextAnchorOutline(t *testing.T) {
+	var (
+		face = loadFace(t)
+		text = NewText("AV")
+	)
+	text.Pos = NewPos(10, 20)
+	text.Font = NewFont(10)
+	text.Anchor = AlignEnd
+	path, err := text.Outline(face)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "M 1 20 L 5 20 L 5 13 L 1 13 L 1 20 Z M 5 13 L 7.5 20 L 10 13 L 5 13 Z"
+	if got := pathData(&path); got != want {
+		t.Errorf("outline mismatched:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestTextPathOutline(t *testing.T) {
+	var (
+		face = loadFace(t)
+		ref  Path
+		text = NewTextPath("AV", "track")
+	)
+	ref.AbsMoveTo(NewPos(0, 0))
+	ref.AbsLineTo(NewPos(100, 0))
+	text.Font = NewFont(10)
+	text.Offset = 10
+
+	path, err := text.Outline(face, ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "M 11 0 L 15 0 L 15 -7 L 11 -7 L 11 0 Z M 15 -7 L 17.5 0 L 20 -7 L 15 -7 Z"
+	if got := pathData(&path); got != want {
+		t.Errorf("outline mismatched:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func loadFace(t *testing.T) *font.Face {
+	t.Helper()
+	face, err := font.Load("font/testdata/tiny.ttf")
+	if err != nil {
+		t.Fatalf("fail to load font: %s", err)
+	}
+	return face
+}
+
+func pathData(p *Path) string {
+	str := p.Attributes()[0]
+	return strings.TrimSuffix(strings.TrimPrefix(str, `d="`), `"`)
+}
@@ -0,0 +1,195 @@
+package svg
+
+import (
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type StyleMode int
+
+const (
+	StyleAttributes StyleMode = iota
+	StyleInline
+	StyleClasses
+)
+
+const styleClassPrefix = "style-"
+
+var presentation = map[string]bool{
+	"fill":              true,
+	"fill-opacity":      true,
+	"fill-rule":         true,
+	"stroke":            true,
+	"stroke-width":      true,
+	"stroke-opacity":    true,
+	"stroke-dasharray":  true,
+	"stroke-dashoffset": true,
+	"stroke-linecap":    true,
+	"stroke-linejoin":   true,
+	"stroke-miterlimit": true,
+	"font-family":       true,
+	"font-size":         true,
+	"font-size-adjust":  true,
+	"font-style":        true,
+	"font-weight":       true,
+	"font-variant":      true,
+	"font-stretch":      true,
+	"text-anchor":       true,
+	"dominant-baseline": true,
+	"stop-color":        true,
+	"stop-opacity":      true,
+	"clip-rule":         true,
+	"shape-rendering":   true,
+}
+
+var lengths = map[string]bool{
+	"font-size":         true,
+	"stroke-width":      true,
+	"stroke-dasharray":  true,
+	"stroke-dashoffset": true,
+}
+
+func (d *document) transform(attrs []string) []string {
+	var (
+		list  []string
+		decls []Declaration
+		class = -1
+		style = -1
+	)
+	for _, a := range attrs {
+		name, value, ok := splitAttr(a)
+		switch {
+		case !ok:
+			list = append(list, a)
+		case presentation[name]:
+			decls = append(decls, Decl(name, cssValue(name, value)))
+		case name == "class":
+			class = len(list)
+			list = append(list, a)
+		case name == "style":
+			style = len(list)
+			list = append(list, a)
+		default:
+			list = append(list, a)
+		}
+	}
+	if len(decls) == 0 {
+		return attrs
+	}
+	decls = mergeDecls(decls)
+	if d.mode == StyleInline {
+		var parts []string
+		for _, d := range decls {
+			parts = append(parts, d.String())
+		}
+		str := strings.Join(parts, "; ")
+		if style >= 0 {
+			_, value, _ := splitAttr(list[style])
+			list[style] = appendString("style", str+"; "+value)
+		} else {
+			list = append(list, appendString("style", str))
+		}
+		return list
+	}
+	name := d.className(decls)
+	if class >= 0 {
+		_, value, _ := splitAttr(list[class])
+		list[class] = appendString("class", value+" "+name)
+	} else {
+		list = append(list, appendString("class", name))
+	}
+	return list
+}
+
+func (d *document) className(decls []Declaration) string {
+	var parts []string
+	for _, d := range decls {
+		parts = append(parts, d.String())
+	}
+	key := strings.Join(parts, ";")
+	if name, ok := d.classes[key]; ok {
+		return name
+	}
+	name := styleClassPrefix + strconv.Itoa(len(d.classes)+1)
+	d.classes[key] = name
+	d.rules = append(d.rules, NewRule("."+name, decls...))
+	return name
+}
+
+func mergeDecls(decls []Declaration) []Declaration {
+	var (
+		seen = make(map[string]int)
+		list []Declaration
+	)
+	for _, d := range decls {
+		if i, ok := seen[d.Property]; ok {
+			list[i] = d
+			continue
+		}
+		seen[d.Property] = len(list)
+		list = append(list, d)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Property < list[j].Property
+	})
+	return list
+}
+
+func cssValue(name, value string) string {
+	if !lengths[name] {
+		return value
+	}
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	for i, f := range fields {
+		if _, err := strconv.ParseFloat(f, 64); err == nil {
+			fields[i] = f + "px"
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+func splitAttr(attr string) (string, string, bool) {
+	i := strings.IndexByte(attr, equal)
+	if i < 0 || i+1 >= len(attr) || attr[i+1] != quote {
+		return "", "", false
+	}
+	value := strings.TrimSuffix(attr[i+2:], string(quote))
+	return attr[:i], html.UnescapeString(value), true
+}
+
+func authorSelectors(s *Style) []string {
+	var list []string
+	if s.Sheet != nil {
+		for _, i := range s.Sheet.items {
+			switch i := i.(type) {
+			case Rule:
+				list = append(list, i.Selectors...)
+			case Media:
+				for _, r := range i.Rules {
+					list = append(list, r.Selectors...)
+				}
+			}
+		}
+	}
+	for str := s.Content; ; {
+		i := strings.IndexByte(str, '{')
+		if i < 0 {
+			break
+		}
+		sel := strings.TrimSpace(str[:i])
+		if j := strings.LastIndexAny(sel, "};"); j >= 0 {
+			sel = strings.TrimSpace(sel[j+1:])
+		}
+		if !strings.HasPrefix(sel, "@") {
+			for _, part := range strings.Split(sel, ",") {
+				list = append(list, strings.TrimSpace(part))
+			}
+		}
+		str = str[i+1:]
+	}
+	return list
+}
@@ -0,0 +1,252 @@
+package qr
+
+import (
+	"strings"
+)
+
+const alphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+var eccCodewords = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var eccBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+type bits struct {
+	data []bool
+}
+
+func (b *bits) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		b.data = append(b.data, (v>>uint(i))&1 == 1)
+	}
+}
+
+func (b *bits) Len() int {
+	return len(b.data)
+}
+
+func (b *bits) bytes() []byte {
+	buf := make([]byte, (len(b.data)+7)/8)
+	for i, on := range b.data {
+		if on {
+			buf[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+	return buf
+}
+
+func detect(str string) Mode {
+	numeric, alnum := true, true
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			numeric = false
+		}
+		if !strings.ContainsRune(alphanumeric, c) {
+			alnum = false
+		}
+	}
+	switch {
+	case numeric:
+		return ModeNumeric
+	case alnum:
+		return ModeAlphanumeric
+	default:
+		return ModeByte
+	}
+}
+
+func (m Mode) valid(str string) bool {
+	switch m {
+	case ModeNumeric, ModeAlphanumeric:
+		return detect(str) <= m
+	default:
+		return true
+	}
+}
+
+func (m Mode) indicator() int {
+	switch m {
+	case ModeNumeric:
+		return 0x1
+	case ModeAlphanumeric:
+		return 0x2
+	default:
+		return 0x4
+	}
+}
+
+func (m Mode) countBits(version int) int {
+	i := 0
+	if version >= 27 {
+		i = 2
+	} else if version >= 10 {
+		i = 1
+	}
+	switch m {
+	case ModeNumeric:
+		return [3]int{10, 12, 14}[i]
+	case ModeAlphanumeric:
+		return [3]int{9, 11, 13}[i]
+	default:
+		return [3]int{8, 16, 16}[i]
+	}
+}
+
+func (m Mode) length(str string) int {
+	if m == ModeByte {
+		return len(str)
+	}
+	return len([]rune(str))
+}
+
+func (m Mode) encode(buf *bits, str string, version int) {
+	buf.append(m.indicator(), 4)
+	buf.append(m.length(str), m.countBits(version))
+	switch m {
+	case ModeNumeric:
+		for i := 0; i < len(str); i += 3 {
+			var (
+				j = i + 3
+				v int
+			)
+			if j > len(str) {
+				j = len(str)
+			}
+			for _, c := range str[i:j] {
+				v = v*10 + int(c-'0')
+			}
+			buf.append(v, (j-i)*3+1)
+		}
+	case ModeAlphanumeric:
+		for i := 0; i < len(str); i += 2 {
+			v := strings.IndexByte(alphanumeric, str[i])
+			if i+1 < len(str) {
+				v = v*45 + strings.IndexByte(alphanumeric, str[i+1])
+				buf.append(v, 11)
+			} else {
+				buf.append(v, 6)
+			}
+		}
+	default:
+		for i := 0; i < len(str); i++ {
+			buf.append(int(str[i]), 8)
+		}
+	}
+}
+
+func terminate(buf *bits, capacity int) {
+	if n := capacity - buf.Len(); n < 4 {
+		buf.append(0, n)
+	} else {
+		buf.append(0, 4)
+	}
+	if n := buf.Len() % 8; n != 0 {
+		buf.append(0, 8-n)
+	}
+	for pad := 0xEC; buf.Len() < capacity; pad ^= 0xEC ^ 0x11 {
+		buf.append(pad, 8)
+	}
+}
+
+func rawModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		n -= (25*align-10)*align - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+func dataCodewords(version int, level Level) int {
+	return rawModules(version)/8 - eccCodewords[level][version]*eccBlocks[level][version]
+}
+
+func interleave(data []byte, version int, level Level) []byte {
+	var (
+		blocks = eccBlocks[level][version]
+		ecc    = eccCodewords[level][version]
+		raw    = rawModules(version) / 8
+		short  = blocks - raw%blocks
+		size   = raw / blocks
+		div    = divisor(ecc)
+		list   [][]byte
+	)
+	for i, k := 0, 0; i < blocks; i++ {
+		n := size - ecc
+		if i >= short {
+			n++
+		}
+		var (
+			dat   = data[k : k+n]
+			block = make([]byte, 0, size+1)
+		)
+		k += n
+		block = append(block, dat...)
+		if i < short {
+			block = append(block, 0)
+		}
+		block = append(block, remainder(dat, div)...)
+		list = append(list, block)
+	}
+	out := make([]byte, 0, raw)
+	for i := range list[0] {
+		for j, block := range list {
+			if i != size-ecc || j >= short {
+				out = append(out, block[i])
+			}
+		}
+	}
+	return out
+}
+
+func divisor(degree int) []byte {
+	var (
+		res  = make([]byte, degree)
+		root = byte(1)
+	)
+	res[degree-1] = 1
+	for i := 0; i < degree; i++ {
+		for j := range res {
+			res[j] = multiply(res[j], root)
+			if j+1 < len(res) {
+				res[j] ^= res[j+1]
+			}
+		}
+		root = multiply(root, 0x02)
+	}
+	return res
+}
+
+func remainder(data, div []byte) []byte {
+	res := make([]byte, len(div))
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i := range res {
+			res[i] ^= multiply(div[i], factor)
+		}
+	}
+	return res
+}
+
+func multiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
@@ -0,0 +1,288 @@
+package qr
+
+const (
+	penaltyRun     = 3
+	penaltyBlock   = 3
+	penaltyFinder  = 40
+	penaltyBalance = 10
+)
+
+type matrix struct {
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+	m := matrix{
+		size:     size,
+		modules:  make([][]bool, size),
+		function: make([][]bool, size),
+	}
+	for i := 0; i < size; i++ {
+		m.modules[i] = make([]bool, size)
+		m.function[i] = make([]bool, size)
+	}
+	return &m
+}
+
+func (m *matrix) set(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+func (m *matrix) patterns(version int) {
+	for i := 0; i < m.size; i++ {
+		m.set(6, i, i%2 == 0)
+		m.set(i, 6, i%2 == 0)
+	}
+	m.finder(3, 3)
+	m.finder(m.size-4, 3)
+	m.finder(3, m.size-4)
+
+	pos := alignments(version)
+	for i := range pos {
+		for j := range pos {
+			last := len(pos) - 1
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			m.alignment(pos[i], pos[j])
+		}
+	}
+	m.format(LevelL, 0)
+	m.version(version)
+}
+
+func (m *matrix) finder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= m.size || yy < 0 || yy >= m.size {
+				continue
+			}
+			d := distance(dx, dy)
+			m.set(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+func (m *matrix) alignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.set(x+dx, y+dy, distance(dx, dy) != 1)
+		}
+	}
+}
+
+func (m *matrix) format(level Level, mask int) {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	code := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		m.set(8, i, bit(code, i))
+	}
+	m.set(8, 7, bit(code, 6))
+	m.set(8, 8, bit(code, 7))
+	m.set(7, 8, bit(code, 8))
+	for i := 9; i < 15; i++ {
+		m.set(14-i, 8, bit(code, i))
+	}
+	for i := 0; i < 8; i++ {
+		m.set(m.size-1-i, 8, bit(code, i))
+	}
+	for i := 8; i < 15; i++ {
+		m.set(8, m.size-15+i, bit(code, i))
+	}
+	m.set(8, m.size-8, true)
+}
+
+func (m *matrix) version(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	code := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		var (
+			dark = bit(code, i)
+			a    = m.size - 11 + i%3
+			b    = i / 3
+		)
+		m.set(a, b, dark)
+		m.set(b, a, dark)
+	}
+}
+
+func (m *matrix) codewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				var (
+					x  = right - j
+					up = (right+1)&2 == 0
+					y  = vert
+				)
+				if up {
+					y = m.size - 1 - vert
+				}
+				if m.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				m.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+func (m *matrix) mask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.function[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+func (m *matrix) penalty() int {
+	var (
+		score int
+		dark  int
+	)
+	for i := 0; i < m.size; i++ {
+		score += m.line(func(j int) bool { return m.modules[i][j] })
+		score += m.line(func(j int) bool { return m.modules[j][i] })
+	}
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			c := m.modules[y][x]
+			if c {
+				dark++
+			}
+			if x+1 < m.size && y+1 < m.size && c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+				score += penaltyBlock
+			}
+		}
+	}
+	total := m.size * m.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*penaltyBalance
+}
+
+func (m *matrix) line(at func(int) bool) int {
+	var (
+		score int
+		run   int
+	)
+	for j := 0; j < m.size; j++ {
+		if j > 0 && at(j) == at(j-1) {
+			run++
+		} else {
+			run = 1
+		}
+		if run == 5 {
+			score += penaltyRun
+		} else if run > 5 {
+			score++
+		}
+	}
+	finder := []bool{true, false, true, true, true, false, true}
+	for j := 0; j+len(finder) <= m.size; j++ {
+		match := true
+		for k, v := range finder {
+			if at(j+k) != v {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if m.light(at, j-4, j) || m.light(at, j+len(finder), j+len(finder)+4) {
+			score += penaltyFinder
+		}
+	}
+	return score
+}
+
+func (m *matrix) light(at func(int) bool, from, to int) bool {
+	for j := from; j < to; j++ {
+		if j >= 0 && j < m.size && at(j) {
+			return false
+		}
+	}
+	return true
+}
+
+func alignments(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	var (
+		count = version/7 + 2
+		step  = (version*8 + count*3 + 5) / (count*4 - 4) * 2
+		list  = make([]int, count)
+		pos   = version*4 + 17 - 7
+	)
+	list[0] = 6
+	for i := count - 1; i >= 1; i-- {
+		list[i] = pos
+		pos -= step
+	}
+	return list
+}
+
+func distance(dx, dy int) int {
+	dx, dy = abs(dx), abs(dy)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+func bit(v, i int) bool {
+	return (v>>uint(i))&1 != 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
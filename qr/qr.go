@@ -0,0 +1,256 @@
+package qr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/midbel/svg"
+)
+
+var (
+	ErrTooLong = errors.New("data too long")
+	ErrMode    = errors.New("data not encodable in mode")
+	ErrVersion = errors.New("invalid version")
+)
+
+const (
+	MinVersion = 1
+	MaxVersion = 40
+
+	defaultModule = 4
+	defaultQuiet  = 4
+)
+
+type Level int
+
+const (
+	LevelL Level = iota
+	LevelM
+	LevelQ
+	LevelH
+)
+
+func (l Level) formatBits() int {
+	switch l {
+	case LevelL:
+		return 1
+	case LevelM:
+		return 0
+	case LevelQ:
+		return 3
+	default:
+		return 2
+	}
+}
+
+type Mode int
+
+const (
+	ModeAuto Mode = iota
+	ModeNumeric
+	ModeAlphanumeric
+	ModeByte
+)
+
+type Mask int
+
+const (
+	MaskAuto Mask = iota
+	Mask0
+	Mask1
+	Mask2
+	Mask3
+	Mask4
+	Mask5
+	Mask6
+	Mask7
+)
+
+type Code struct {
+	Version int
+	Level   Level
+	Mode    Mode
+	Mask    int
+	Size    int
+	modules [][]bool
+}
+
+func (c Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+func (c Code) Path(module float64, quiet int) svg.Path {
+	var path svg.Path
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; {
+			if !c.modules[y][x] {
+				x++
+				continue
+			}
+			start := x
+			for x < c.Size && c.modules[y][x] {
+				x++
+			}
+			pos := svg.NewPos(float64(start+quiet)*module, float64(y+quiet)*module)
+			path.AbsMoveTo(pos)
+			path.RelHorizontalLine(float64(x-start) * module)
+			path.RelVerticalLine(module)
+			path.RelHorizontalLine(-float64(x-start) * module)
+			path.ClosePath()
+		}
+	}
+	return path
+}
+
+type QR struct {
+	Text       string
+	Level      Level
+	Mode       Mode
+	Version    int
+	Mask       Mask
+	Module     float64
+	Quiet      int
+	Color      string
+	Background string
+}
+
+func (q QR) Encode() (Code, error) {
+	var (
+		code = Code{Level: q.Level, Mode: q.Mode}
+		min  = MinVersion
+		max  = MaxVersion
+	)
+	if q.Level < LevelL || q.Level > LevelH {
+		code.Level = LevelL
+	}
+	if q.Version != 0 {
+		if q.Version < MinVersion || q.Version > MaxVersion {
+			return code, fmt.Errorf("%w: %d", ErrVersion, q.Version)
+		}
+		min, max = q.Version, q.Version
+	}
+	if code.Mode == ModeAuto {
+		code.Mode = detect(q.Text)
+	} else if !code.Mode.valid(q.Text) {
+		return code, ErrMode
+	}
+	var buf bits
+	for v := min; v <= max; v++ {
+		capacity := dataCodewords(v, code.Level) * 8
+		buf = bits{}
+		code.Mode.encode(&buf, q.Text, v)
+		if code.Mode.length(q.Text) >= 1<<uint(code.Mode.countBits(v)) {
+			continue
+		}
+		if buf.Len() <= capacity {
+			code.Version = v
+			break
+		}
+	}
+	if code.Version == 0 {
+		return code, ErrTooLong
+	}
+	terminate(&buf, dataCodewords(code.Version, code.Level)*8)
+
+	m := newMatrix(code.Version)
+	m.patterns(code.Version)
+	m.codewords(interleave(buf.bytes(), code.Version, code.Level))
+
+	code.Mask = int(q.Mask) - 1
+	if q.Mask <= MaskAuto || q.Mask > Mask7 {
+		best := -1
+		for i := 0; i < 8; i++ {
+			m.mask(i)
+			m.format(code.Level, i)
+			if p := m.penalty(); best < 0 || p < best {
+				best = p
+				code.Mask = i
+			}
+			m.mask(i)
+		}
+	}
+	m.mask(code.Mask)
+	m.format(code.Level, code.Mask)
+
+	code.Size = m.size
+	code.modules = m.modules
+	return code, nil
+}
+
+func (q QR) Render(w io.Writer) error {
+	code, err := q.Encode()
+	if err != nil {
+		return err
+	}
+	ws := bufio.NewWriter(w)
+	defer ws.Flush()
+
+	el := q.render(code)
+	el.Render(ws)
+	return nil
+}
+
+func (q QR) Element() svg.Element {
+	code, _ := q.Encode()
+	return q.render(code)
+}
+
+func (q QR) render(code Code) svg.Element {
+	var (
+		canvas = svg.NewSVG()
+		module = q.module()
+		quiet  = q.quiet()
+	)
+	var (
+		side = float64(code.Size + 2*quiet)
+		path = code.Path(1, quiet)
+	)
+	canvas.Dim = svg.NewDim(side*module, side*module)
+	canvas.ViewBox.Dim = svg.NewDim(side, side)
+	canvas.Class = append(canvas.Class, "qrcode")
+	if q.Background != "none" {
+		var rect svg.Rect
+		rect.Dim = svg.NewDim(side, side)
+		rect.Fill = svg.NewFill(q.background())
+		canvas.Append(rect.AsElement())
+	}
+	path.Fill = svg.NewFill(q.color())
+	canvas.Append(path.AsElement())
+	return canvas.AsElement()
+}
+
+func (q QR) module() float64 {
+	if q.Module <= 0 {
+		return defaultModule
+	}
+	return q.Module
+}
+
+func (q QR) quiet() int {
+	if q.Quiet < 0 {
+		return 0
+	}
+	if q.Quiet == 0 {
+		return defaultQuiet
+	}
+	return q.Quiet
+}
+
+func (q QR) color() string {
+	if q.Color == "" {
+		return "#000000"
+	}
+	return q.Color
+}
+
+func (q QR) background() string {
+	if q.Background == "" {
+		return "#ffffff"
+	}
+	return q.Background
+}
@@ -0,0 +1,204 @@
+package qr
+
+import (
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodewords(t *testing.T) {
+	data := []struct {
+		Input   string
+		Mode    Mode
+		Version int
+		Level   Level
+		Data    []byte
+		Ecc     []byte
+	}{
+		{
+			Input:   "01234567",
+			Mode:    ModeNumeric,
+			Version: 1,
+			Level:   LevelM,
+			Data:    []byte{16, 32, 12, 86, 97, 128, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17},
+			Ecc:     []byte{165, 36, 212, 193, 237, 54, 199, 135, 44, 85},
+		},
+		{
+			Input:   "HELLO WORLD",
+			Mode:    ModeAlphanumeric,
+			Version: 1,
+			Level:   LevelQ,
+			Data:    []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236},
+			Ecc:     []byte{168, 72, 22, 82, 217, 54, 156, 0, 46, 15, 180, 122, 16},
+		},
+	}
+	for _, d := range data {
+		var buf bits
+		d.Mode.encode(&buf, d.Input, d.Version)
+		terminate(&buf, dataCodewords(d.Version, d.Level)*8)
+
+		got := buf.bytes()
+		if !reflect.DeepEqual(got, d.Data) {
+			t.Errorf("%s: data codewords mismatched: want %v, got %v", d.Input, d.Data, got)
+			continue
+		}
+		got = interleave(got, d.Version, d.Level)
+		if want := append(d.Data, d.Ecc...); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: codewords mismatched: want %v, got %v", d.Input, want, got)
+		}
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	data := make([]byte, dataCodewords(5, LevelQ))
+	for i := range data {
+		data[i] = byte(i)
+	}
+	got := interleave(data, 5, LevelQ)
+	if len(got) != rawModules(5)/8 {
+		t.Fatalf("want %d codewords, got %d", rawModules(5)/8, len(got))
+	}
+	want := []byte{0, 15, 30, 46, 1, 16, 31, 47}
+	if !reflect.DeepEqual(got[:len(want)], want) {
+		t.Errorf("first codewords mismatched: want %v, got %v", want, got[:len(want)])
+	}
+	if tail := got[len(data)-2 : len(data)]; !reflect.DeepEqual(tail, []byte{45, 61}) {
+		t.Errorf("last data codewords mismatched: want [45 61], got %v", tail)
+	}
+}
+
+func TestDataCodewords(t *testing.T) {
+	data := []struct {
+		Version int
+		Level   Level
+		Want    int
+	}{
+		{Version: 1, Level: LevelL, Want: 19},
+		{Version: 1, Level: LevelM, Want: 16},
+		{Version: 1, Level: LevelQ, Want: 13},
+		{Version: 1, Level: LevelH, Want: 9},
+		{Version: 7, Level: LevelM, Want: 124},
+		{Version: 40, Level: LevelL, Want: 2956},
+		{Version: 40, Level: LevelH, Want: 1276},
+	}
+	for _, d := range data {
+		if got := dataCodewords(d.Version, d.Level); got != d.Want {
+			t.Errorf("%d-%d: want %d data codewords, got %d", d.Version, d.Level, d.Want, got)
+		}
+	}
+}
+
+func TestFormatInfo(t *testing.T) {
+	data := []struct {
+		Level Level
+		Mask  Mask
+		Want  string
+	}{
+		{Level: LevelL, Mask: Mask0, Want: "111011111000100"},
+		{Level: LevelM, Mask: Mask0, Want: "101010000010010"},
+		{Level: LevelQ, Mask: Mask0, Want: "011010101011111"},
+		{Level: LevelH, Mask: Mask0, Want: "001011010001001"},
+		{Level: LevelM, Mask: Mask5, Want: "100000011001110"},
+	}
+	for _, d := range data {
+		code, err := QR{Text: "HELLO WORLD", Level: d.Level, Mask: d.Mask}.Encode()
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+		var bits []bool
+		for i := 0; i <= 5; i++ {
+			bits = append(bits, code.Dark(8, i))
+		}
+		bits = append(bits, code.Dark(8, 7), code.Dark(8, 8), code.Dark(7, 8))
+		for i := 9; i < 15; i++ {
+			bits = append(bits, code.Dark(14-i, 8))
+		}
+		var str strings.Builder
+		for i := len(bits) - 1; i >= 0; i-- {
+			if bits[i] {
+				str.WriteByte('1')
+			} else {
+				str.WriteByte('0')
+			}
+		}
+		if got := str.String(); got != d.Want {
+			t.Errorf("%d/%d: format bits mismatched: want %s, got %s", d.Level, d.Mask, d.Want, got)
+		}
+	}
+}
+
+func TestVersionInfo(t *testing.T) {
+	code, err := QR{Text: "HELLO WORLD", Version: 7}.Encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	const want = 0x07C94
+	for i := 0; i < 18; i++ {
+		var (
+			dark = want>>uint(i)&1 == 1
+			a    = code.Size - 11 + i%3
+			b    = i / 3
+		)
+		if code.Dark(a, b) != dark || code.Dark(b, a) != dark {
+			t.Errorf("version bit %d mismatched", i)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	data := []struct {
+		QR      QR
+		Version int
+		Size    int
+		Mode    Mode
+	}{
+		{QR: QR{Text: "HELLO WORLD", Level: LevelQ}, Version: 1, Size: 21, Mode: ModeAlphanumeric},
+		{QR: QR{Text: "hello, world", Level: LevelM}, Version: 1, Size: 21, Mode: ModeByte},
+		{QR: QR{Text: strings.Repeat("7", 42), Level: LevelL}, Version: 2, Size: 25, Mode: ModeNumeric},
+		{QR: QR{Text: strings.Repeat("a", 100), Level: LevelH}, Version: 10, Size: 57, Mode: ModeByte},
+	}
+	for _, d := range data {
+		code, err := d.QR.Encode()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.QR.Text, err)
+			continue
+		}
+		if code.Version != d.Version || code.Size != d.Size || code.Mode != d.Mode {
+			t.Errorf("%s: want version %d (size %d, mode %d), got version %d (size %d, mode %d)", d.QR.Text, d.Version, d.Size, d.Mode, code.Version, code.Size, code.Mode)
+		}
+		for _, p := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+			if !code.Dark(p[0], p[1]) || !code.Dark(p[0]+3, p[1]+3) || code.Dark(p[0]+1, p[1]+1) {
+				t.Errorf("%s: finder pattern missing at %v", d.QR.Text, p)
+			}
+		}
+		for i := 8; i < code.Size-8; i++ {
+			if code.Dark(i, 6) != (i%2 == 0) || code.Dark(6, i) != (i%2 == 0) {
+				t.Errorf("%s: timing pattern broken at %d", d.QR.Text, i)
+				break
+			}
+		}
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	data := []struct {
+		QR  QR
+		Err error
+	}{
+		{QR: QR{Text: "hello", Mode: ModeNumeric}, Err: ErrMode},
+		{QR: QR{Text: "HELLO", Version: 41}, Err: ErrVersion},
+		{QR: QR{Text: strings.Repeat("a", 20), Version: 1}, Err: ErrTooLong},
+		{QR: QR{Text: strings.Repeat("a", 3000), Level: LevelH}, Err: ErrTooLong},
+	}
+	for _, d := range data {
+		if _, err := d.QR.Encode(); !errors.Is(err, d.Err) {
+			t.Errorf("encode: want %v, got %v", d.Err, err)
+		}
+		if err := d.QR.Render(ioutil.Discard); !errors.Is(err, d.Err) {
+			t.Errorf("render: want %v, got %v", d.Err, err)
+		}
+	}
+}
@@ -0,0 +1,446 @@
+package svg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrSelector = errors.New("invalid selector")
+
+func (s *SVG) QuerySelector(sel string) (Element, error) {
+	list, err := query(s, sel, true)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+func (s *SVG) QuerySelectorAll(sel string) ([]Element, error) {
+	return query(s, sel, false)
+}
+
+func query(root *SVG, sel string, first bool) ([]Element, error) {
+	groups, err := parseSelector(sel)
+	if err != nil {
+		return nil, err
+	}
+	return match(root, groups, first), nil
+}
+
+func match(root Container, groups []selector, first bool) []Element {
+	var (
+		list  []Element
+		stack = []frame{{tag: describe(root)}}
+		visit func(Container) bool
+	)
+	visit = func(c Container) bool {
+		var index int
+		for _, e := range c.Children() {
+			t := describe(e)
+			if t.Name == "" {
+				continue
+			}
+			index++
+			stack = append(stack, frame{tag: t, index: index})
+			for _, g := range groups {
+				if g.match(stack) {
+					list = append(list, e)
+					break
+				}
+			}
+			if first && len(list) > 0 {
+				return true
+			}
+			if c, ok := e.(Container); ok && visit(c) {
+				return true
+			}
+			stack = stack[:len(stack)-1]
+		}
+		return false
+	}
+	visit(root)
+	return list
+}
+
+type tagger interface {
+	tag() (string, []Attribute)
+}
+
+type tag struct {
+	Name  string
+	Attrs map[string]string
+}
+
+func (t tag) classes() []string {
+	return strings.Fields(t.Attrs["class"])
+}
+
+type frame struct {
+	tag
+	index int
+}
+
+func describe(e Element) tag {
+	var t tag
+	el, ok := e.(tagger)
+	if !ok {
+		return t
+	}
+	name, attrs := el.tag()
+	if n, ok := e.(identified); ok {
+		attrs = append(attrs, n.identity())
+	}
+	t.Name = name
+	t.Attrs = make(map[string]string)
+	for _, a := range attrs {
+		for _, str := range a.Attributes() {
+			if name, value, ok := splitAttr(str); ok {
+				t.Attrs[name] = value
+			}
+		}
+	}
+	return t
+}
+
+type combinator byte
+
+const (
+	descendant combinator = ' '
+	child      combinator = '>'
+)
+
+type compound struct {
+	Name    string
+	Id      string
+	Classes []string
+	Attrs   []attrSelector
+	Nth     []nth
+	Link    combinator
+}
+
+func (c compound) match(f frame) bool {
+	if c.Name != "" && c.Name != "*" && c.Name != f.Name {
+		return false
+	}
+	if c.Id != "" && c.Id != f.Attrs["id"] {
+		return false
+	}
+	classes := f.classes()
+	for _, want := range c.Classes {
+		if !contains(classes, want) {
+			return false
+		}
+	}
+	for _, a := range c.Attrs {
+		if !a.match(f.Attrs) {
+			return false
+		}
+	}
+	for _, n := range c.Nth {
+		if !n.match(f.index) {
+			return false
+		}
+	}
+	return true
+}
+
+type selector []compound
+
+func (s selector) match(stack []frame) bool {
+	return s.matchAt(len(s)-1, stack, len(stack)-1)
+}
+
+func (s selector) matchAt(i int, stack []frame, j int) bool {
+	if !s[i].match(stack[j]) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch s[i].Link {
+	case child:
+		return j > 0 && s.matchAt(i-1, stack, j-1)
+	default:
+		for k := j - 1; k >= 0; k-- {
+			if s.matchAt(i-1, stack, k) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type attrSelector struct {
+	Name  string
+	Op    string
+	Value string
+}
+
+func (a attrSelector) match(attrs map[string]string) bool {
+	v, ok := attrs[a.Name]
+	if !ok {
+		return false
+	}
+	switch a.Op {
+	case "":
+		return true
+	case "=":
+		return v == a.Value
+	case "~=":
+		return contains(strings.Fields(v), a.Value)
+	case "|=":
+		return v == a.Value || strings.HasPrefix(v, a.Value+"-")
+	case "^=":
+		return a.Value != "" && strings.HasPrefix(v, a.Value)
+	case "$=":
+		return a.Value != "" && strings.HasSuffix(v, a.Value)
+	case "*=":
+		return a.Value != "" && strings.Contains(v, a.Value)
+	default:
+		return false
+	}
+}
+
+type nth struct {
+	A int
+	B int
+}
+
+func (n nth) match(index int) bool {
+	if n.A == 0 {
+		return index == n.B
+	}
+	diff := index - n.B
+	return diff%n.A == 0 && diff/n.A >= 0
+}
+
+func parseSelector(str string) ([]selector, error) {
+	var list []selector
+	for _, part := range splitUnquoted(str, ',') {
+		sel, err := parseGroup(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrSelector, str, err)
+		}
+		list = append(list, sel)
+	}
+	return list, nil
+}
+
+func parseGroup(str string) (selector, error) {
+	var (
+		sel  selector
+		link = descendant
+	)
+	if str == "" {
+		return nil, fmt.Errorf("empty selector")
+	}
+	for str != "" {
+		c, rest, err := parseCompound(str)
+		if err != nil {
+			return nil, err
+		}
+		c.Link = link
+		sel = append(sel, c)
+
+		str = strings.TrimLeft(rest, " \t\n")
+		link = descendant
+		if strings.HasPrefix(str, ">") {
+			link = child
+			str = strings.TrimLeft(str[1:], " \t\n")
+			if str == "" {
+				return nil, fmt.Errorf("missing selector after >")
+			}
+		}
+	}
+	return sel, nil
+}
+
+func parseCompound(str string) (compound, string, error) {
+	var c compound
+	if str != "" && str[0] == '>' {
+		return c, str, fmt.Errorf("unexpected >")
+	}
+	c.Name, str = ident(str)
+	if str != "" && str[0] == '*' && c.Name == "" {
+		c.Name, str = "*", str[1:]
+	}
+	for str != "" {
+		var name string
+		switch str[0] {
+		case '#':
+			name, str = ident(str[1:])
+			if name == "" {
+				return c, str, fmt.Errorf("missing id")
+			}
+			c.Id = name
+		case '.':
+			name, str = ident(str[1:])
+			if name == "" {
+				return c, str, fmt.Errorf("missing class")
+			}
+			c.Classes = append(c.Classes, name)
+		case '[':
+			end := indexUnquoted(str, ']')
+			if end < 0 {
+				return c, str, fmt.Errorf("missing ]")
+			}
+			a, err := parseAttr(str[1:end])
+			if err != nil {
+				return c, str, err
+			}
+			c.Attrs = append(c.Attrs, a)
+			str = str[end+1:]
+		case ':':
+			name, str = ident(str[1:])
+			switch name {
+			case "first-child":
+				c.Nth = append(c.Nth, nth{B: 1})
+			case "nth-child":
+				end := strings.IndexByte(str, ')')
+				if !strings.HasPrefix(str, "(") || end < 0 {
+					return c, str, fmt.Errorf("missing argument for :nth-child")
+				}
+				n, err := parseNth(str[1:end])
+				if err != nil {
+					return c, str, err
+				}
+				c.Nth = append(c.Nth, n)
+				str = str[end+1:]
+			default:
+				return c, str, fmt.Errorf("unsupported pseudo-class :%s", name)
+			}
+		case ' ', '\t', '\n', '>':
+			return c, str, nil
+		default:
+			return c, str, fmt.Errorf("unexpected %q", str[0])
+		}
+	}
+	return c, str, nil
+}
+
+func parseAttr(str string) (attrSelector, error) {
+	var a attrSelector
+	i := strings.IndexAny(str, "~|^$*=")
+	if i < 0 {
+		a.Name = strings.TrimSpace(str)
+	} else {
+		a.Name = strings.TrimSpace(str[:i])
+		op := str[i:]
+		if op[0] != '=' {
+			if len(op) < 2 || op[1] != '=' {
+				return a, fmt.Errorf("invalid attribute operator in [%s]", str)
+			}
+			a.Op, a.Value = op[:2], op[2:]
+		} else {
+			a.Op, a.Value = op[:1], op[1:]
+		}
+		a.Value = strings.TrimSpace(a.Value)
+		if a.Value != "" && (a.Value[0] == '"' || a.Value[0] == '\'') {
+			n := len(a.Value)
+			if n < 2 || a.Value[n-1] != a.Value[0] || strings.IndexByte(a.Value[1:], a.Value[0]) != n-2 {
+				return a, fmt.Errorf("unterminated string in [%s]", str)
+			}
+			a.Value = a.Value[1 : n-1]
+		}
+	}
+	if a.Name == "" {
+		return a, fmt.Errorf("missing attribute name")
+	}
+	for _, part := range strings.SplitN(a.Name, ":", 2) {
+		if name, rest := ident(part); name == "" || rest != "" {
+			return a, fmt.Errorf("invalid attribute name %q", a.Name)
+		}
+	}
+	return a, nil
+}
+
+func splitUnquoted(str string, sep byte) []string {
+	var list []string
+	for {
+		i := indexUnquoted(str, sep)
+		if i < 0 {
+			return append(list, str)
+		}
+		list = append(list, str[:i])
+		str = str[i+1:]
+	}
+}
+
+func indexUnquoted(str string, c byte) int {
+	var quote byte
+	for i := 0; i < len(str); i++ {
+		switch {
+		case quote != 0:
+			if str[i] == quote {
+				quote = 0
+			}
+		case str[i] == c:
+			return i
+		case str[i] == '"' || str[i] == '\'':
+			quote = str[i]
+		}
+	}
+	return -1
+}
+
+func parseNth(str string) (nth, error) {
+	var n nth
+	str = strings.ReplaceAll(strings.TrimSpace(str), " ", "")
+	switch str {
+	case "odd":
+		return nth{A: 2, B: 1}, nil
+	case "even":
+		return nth{A: 2, B: 0}, nil
+	}
+	i := strings.IndexByte(str, 'n')
+	if i < 0 {
+		b, err := strconv.Atoi(str)
+		if err != nil {
+			return n, fmt.Errorf("invalid :nth-child(%s)", str)
+		}
+		return nth{B: b}, nil
+	}
+	switch a := str[:i]; a {
+	case "", "+":
+		n.A = 1
+	case "-":
+		n.A = -1
+	default:
+		v, err := strconv.Atoi(a)
+		if err != nil {
+			return n, fmt.Errorf("invalid :nth-child(%s)", str)
+		}
+		n.A = v
+	}
+	if b := str[i+1:]; b != "" {
+		v, err := strconv.Atoi(b)
+		if err != nil {
+			return n, fmt.Errorf("invalid :nth-child(%s)", str)
+		}
+		n.B = v
+	}
+	return n, nil
+}
+
+func ident(str string) (string, string) {
+	i := 0
+	for ; i < len(str); i++ {
+		c := str[i]
+		if c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			continue
+		}
+		break
+	}
+	return str[:i], str[i:]
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
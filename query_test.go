@@ -0,0 +1,183 @@
+package svg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	data := []struct {
+		Input string
+		Want  []selector
+	}{
+		{
+			Input: "rect",
+			Want:  []selector{{{Name: "rect", Link: descendant}}},
+		},
+		{
+			Input: "g.bar#first",
+			Want:  []selector{{{Name: "g", Id: "first", Classes: []string{"bar"}, Link: descendant}}},
+		},
+		{
+			Input: "svg > g rect",
+			Want: []selector{{
+				{Name: "svg", Link: descendant},
+				{Name: "g", Link: child},
+				{Name: "rect", Link: descendant},
+			}},
+		},
+		{
+			Input: `*[fill^="url(#"]`,
+			Want:  []selector{{{Name: "*", Attrs: []attrSelector{{Name: "fill", Op: "^=", Value: "url(#"}}, Link: descendant}}},
+		},
+		{
+			Input: "use[xlink:href]",
+			Want:  []selector{{{Name: "use", Attrs: []attrSelector{{Name: "xlink:href"}}, Link: descendant}}},
+		},
+		{
+			Input: `text[data-label="a,b"], rect`,
+			Want: []selector{
+				{{Name: "text", Attrs: []attrSelector{{Name: "data-label", Op: "=", Value: "a,b"}}, Link: descendant}},
+				{{Name: "rect", Link: descendant}},
+			},
+		},
+		{
+			Input: `[title="x]"][data-note='it"s']`,
+			Want: []selector{{{Attrs: []attrSelector{
+				{Name: "title", Op: "=", Value: "x]"},
+				{Name: "data-note", Op: "=", Value: `it"s`},
+			}, Link: descendant}}},
+		},
+		{
+			Input: "circle:nth-child(2n+1), text:first-child",
+			Want: []selector{
+				{{Name: "circle", Nth: []nth{{A: 2, B: 1}}, Link: descendant}},
+				{{Name: "text", Nth: []nth{{B: 1}}, Link: descendant}},
+			},
+		},
+	}
+	for _, d := range data {
+		got, err := parseSelector(d.Input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%s: selectors mismatched: want %+v, got %+v", d.Input, d.Want, got)
+		}
+	}
+}
+
+func TestParseSelectorInvalid(t *testing.T) {
+	data := []string{
+		"",
+		"rect,",
+		"g >",
+		"> g",
+		"#",
+		".",
+		"[fill",
+		"[=red]",
+		"[fill!=red]",
+		`[title="x]`,
+		`[title="x"y"]`,
+		`rect[data-label="a,b], text`,
+		"rect:hover",
+		"rect:nth-child(x)",
+		"rect:nth-child",
+		"rect + circle",
+	}
+	for _, str := range data {
+		_, err := parseSelector(str)
+		if !errors.Is(err, ErrSelector) {
+			t.Errorf("%q: expected ErrSelector, got %v", str, err)
+		}
+	}
+}
+
+func TestParseNth(t *testing.T) {
+	data := []struct {
+		Input string
+		Want  nth
+		Match []int
+	}{
+		{Input: "3", Want: nth{B: 3}, Match: []int{3}},
+		{Input: "odd", Want: nth{A: 2, B: 1}, Match: []int{1, 3, 5}},
+		{Input: "even", Want: nth{A: 2}, Match: []int{2, 4, 6}},
+		{Input: "n+2", Want: nth{A: 1, B: 2}, Match: []int{2, 3, 4, 5, 6}},
+		{Input: "-n + 3", Want: nth{A: -1, B: 3}, Match: []int{1, 2, 3}},
+		{Input: "3n-1", Want: nth{A: 3, B: -1}, Match: []int{2, 5}},
+	}
+	for _, d := range data {
+		got, err := parseNth(d.Input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if got != d.Want {
+			t.Errorf("%s: want %+v, got %+v", d.Input, d.Want, got)
+		}
+		var match []int
+		for i := 1; i <= 6; i++ {
+			if got.match(i) {
+				match = append(match, i)
+			}
+		}
+		if !reflect.DeepEqual(match, d.Match) {
+			t.Errorf("%s: indices mismatched: want %v, got %v", d.Input, d.Match, match)
+		}
+	}
+}
+
+func TestQuerySelector(t *testing.T) {
+	var (
+		root  = NewSVG()
+		group Group
+		text  = NewText("label")
+	)
+	group.Id = "bars"
+	group.Class = append(group.Class, "series")
+	for i := 0; i < 3; i++ {
+		var r Rect
+		r.Fill = NewFill("url(#grad)")
+		if i == 1 {
+			r.Class = append(r.Class, "bar", "active")
+		}
+		group.Append(r.AsElement())
+	}
+	root.Append(group.AsElement())
+	root.Append(text.AsElement())
+
+	data := []struct {
+		Input string
+		Want  int
+	}{
+		{Input: "rect", Want: 3},
+		{Input: "#bars > rect", Want: 3},
+		{Input: "svg > rect", Want: 0},
+		{Input: "g.series rect.active", Want: 1},
+		{Input: "rect:nth-child(odd)", Want: 2},
+		{Input: `[fill="url(#grad)"]`, Want: 3},
+		{Input: "[class~=bar]", Want: 1},
+		{Input: "text, g", Want: 2},
+		{Input: "circle", Want: 0},
+	}
+	for _, d := range data {
+		list, err := root.QuerySelectorAll(d.Input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", d.Input, err)
+			continue
+		}
+		if len(list) != d.Want {
+			t.Errorf("%s: want %d element(s), got %d", d.Input, d.Want, len(list))
+		}
+	}
+	e, err := root.QuerySelector("rect.bar")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e != group.List.List[1] {
+		t.Errorf("rect.bar: wrong element selected")
+	}
+}
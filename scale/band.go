@@ -0,0 +1,211 @@
+package scale
+
+import (
+	"math"
+)
+
+type Band struct {
+	Domain       []string
+	Range        Range
+	PaddingInner float64
+	PaddingOuter float64
+	Align        float64
+	Format       func(string) string
+}
+
+func NewBand(domain []string, rg Range) Band {
+	return Band{
+		Domain: domain,
+		Range:  rg,
+		Align:  0.5,
+	}
+}
+
+func (s Band) Scale(v string) float64 {
+	i := s.Index(v)
+	if i < 0 {
+		return math.NaN()
+	}
+	return s.at(i)
+}
+
+func (s Band) Center(v string) float64 {
+	return s.Scale(v) + s.Bandwidth()/2
+}
+
+func (s Band) Invert(v float64) string {
+	n := len(s.Domain)
+	if n == 0 {
+		return ""
+	}
+	var (
+		step  = s.Step()
+		start = s.start()
+		i     = int(math.Floor((v - start) / step))
+	)
+	if i < 0 || i >= n || v-start-float64(i)*step > s.Bandwidth() {
+		return ""
+	}
+	if s.reversed() {
+		i = n - 1 - i
+	}
+	return s.Domain[i]
+}
+
+func (s Band) Index(v string) int {
+	for i := range s.Domain {
+		if s.Domain[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s Band) Step() float64 {
+	n := float64(len(s.Domain))
+	if n == 0 {
+		return 0
+	}
+	return math.Abs(s.Range.Len()) / math.Max(1, n-s.inner()+2*s.PaddingOuter)
+}
+
+func (s Band) Bandwidth() float64 {
+	return s.Step() * (1 - s.inner())
+}
+
+func (s Band) Extent() Range {
+	return s.Range
+}
+
+func (s Band) Ticks(count int) []Tick {
+	var (
+		ticks []Tick
+		every = 1
+	)
+	if n := len(s.Domain); count > 0 && n > count {
+		every = int(math.Ceil(float64(n) / float64(count)))
+	}
+	for i := 0; i < len(s.Domain); i += every {
+		label := s.Domain[i]
+		if s.Format != nil {
+			label = s.Format(label)
+		}
+		t := Tick{
+			Offset: s.at(i) + s.Bandwidth()/2,
+			Label:  label,
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+func (s Band) at(i int) float64 {
+	if s.reversed() {
+		i = len(s.Domain) - 1 - i
+	}
+	return s.start() + float64(i)*s.Step()
+}
+
+func (s Band) start() float64 {
+	var (
+		n    = float64(len(s.Domain))
+		step = s.Step()
+		rest = math.Abs(s.Range.Len()) - step*(n-s.inner())
+	)
+	return s.Range.Min() + rest*s.Align
+}
+
+func (s Band) inner() float64 {
+	return math.Max(0, math.Min(1, s.PaddingInner))
+}
+
+func (s Band) reversed() bool {
+	return s.Range.Starts > s.Range.Ends
+}
+
+type Point struct {
+	Domain  []string
+	Range   Range
+	Padding float64
+	Align   float64
+	Format  func(string) string
+}
+
+func NewPoint(domain []string, rg Range) Point {
+	return Point{
+		Domain: domain,
+		Range:  rg,
+		Align:  0.5,
+	}
+}
+
+func (s Point) Scale(v string) float64 {
+	return s.band().Scale(v)
+}
+
+func (s Point) Invert(v float64) string {
+	var (
+		b    = s.band()
+		n    = len(s.Domain)
+		step = b.Step()
+	)
+	if n == 0 || step == 0 {
+		return ""
+	}
+	i := int(math.Round((v - b.start()) / step))
+	if i < 0 || i >= n {
+		return ""
+	}
+	if b.reversed() {
+		i = n - 1 - i
+	}
+	return s.Domain[i]
+}
+
+func (s Point) Step() float64 {
+	return s.band().Step()
+}
+
+func (s Point) Extent() Range {
+	return s.Range
+}
+
+func (s Point) Ticks(count int) []Tick {
+	return s.band().Ticks(count)
+}
+
+func (s Point) band() Band {
+	return Band{
+		Domain:       s.Domain,
+		Range:        s.Range,
+		PaddingInner: 1,
+		PaddingOuter: s.Padding,
+		Align:        s.Align,
+		Format:       s.Format,
+	}
+}
+
+type Ordinal struct {
+	Domain  []string
+	Values  []string
+	Unknown string
+}
+
+func NewOrdinal(domain, values []string) Ordinal {
+	return Ordinal{
+		Domain: domain,
+		Values: values,
+	}
+}
+
+func (s Ordinal) Scale(v string) string {
+	if len(s.Values) == 0 {
+		return s.Unknown
+	}
+	for i := range s.Domain {
+		if s.Domain[i] == v {
+			return s.Values[i%len(s.Values)]
+		}
+	}
+	return s.Unknown
+}
@@ -0,0 +1,176 @@
+package scale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	Blues   = []string{"#f7fbff", "#c6dbef", "#6baed6", "#2171b5", "#08306b"}
+	Greens  = []string{"#f7fcf5", "#c7e9c0", "#74c476", "#238b45", "#00441b"}
+	Reds    = []string{"#fff5f0", "#fcbba1", "#fb6a4a", "#cb181d", "#67000d"}
+	Viridis = []string{"#440154", "#3b528b", "#21918c", "#5ec962", "#fde725"}
+	RdBu    = []string{"#67001f", "#d6604d", "#f7f7f7", "#4393c3", "#053061"}
+	RdYlGn  = []string{"#a50026", "#f46d43", "#ffffbf", "#66bd63", "#006837"}
+)
+
+type Color struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+func ParseColor(str string) (Color, error) {
+	var c Color
+	if !strings.HasPrefix(str, "#") {
+		return c, fmt.Errorf("%s: unsupported color", str)
+	}
+	str = str[1:]
+	if len(str) == 3 {
+		str = string([]byte{str[0], str[0], str[1], str[1], str[2], str[2]})
+	}
+	if len(str) != 6 {
+		return c, fmt.Errorf("#%s: invalid color", str)
+	}
+	n, err := strconv.ParseUint(str, 16, 32)
+	if err != nil {
+		return c, fmt.Errorf("#%s: invalid color", str)
+	}
+	c.R = uint8(n >> 16)
+	c.G = uint8(n >> 8)
+	c.B = uint8(n)
+	return c, nil
+}
+
+func (c Color) Mix(other Color, t float64) Color {
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + t*(float64(b)-float64(a))))
+	}
+	return Color{
+		R: mix(c.R, other.R),
+		G: mix(c.G, other.G),
+		B: mix(c.B, other.B),
+	}
+}
+
+func (c Color) Luminance() float64 {
+	return (0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)) / 255
+}
+
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+type ColorScale interface {
+	Color(float64) string
+	Extent() Range
+}
+
+func Sample(s ColorScale, count int) []string {
+	if count < 2 {
+		count = 2
+	}
+	var (
+		rg   = s.Extent()
+		list = make([]string, count)
+	)
+	for i := range list {
+		list[i] = s.Color(rg.lerp(float64(i) / float64(count-1)))
+	}
+	return list
+}
+
+type Sequential struct {
+	Domain Range
+	Colors []string
+}
+
+func NewSequential(domain Range, colors []string) Sequential {
+	return Sequential{
+		Domain: domain,
+		Colors: colors,
+	}
+}
+
+func (s Sequential) Color(v float64) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return interpolate(s.Colors, s.Domain.ratio(s.Domain.clamp(v)))
+}
+
+func (s Sequential) Extent() Range {
+	return s.Domain
+}
+
+func (s Sequential) Nice(count int) Sequential {
+	s.Domain = niceRange(s.Domain, count)
+	return s
+}
+
+type Diverging struct {
+	Domain Range
+	Mid    float64
+	Colors []string
+}
+
+func NewDiverging(domain Range, mid float64, colors []string) Diverging {
+	return Diverging{
+		Domain: domain,
+		Mid:    mid,
+		Colors: colors,
+	}
+}
+
+func (s Diverging) Color(v float64) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	var (
+		lo = NewRange(s.Domain.Starts, s.Mid)
+		hi = NewRange(s.Mid, s.Domain.Ends)
+		t  float64
+	)
+	v = s.Domain.clamp(v)
+	if lo.Contains(v) && lo.Len() != 0 {
+		t = lo.ratio(v) / 2
+	} else if hi.Len() != 0 {
+		t = 0.5 + hi.ratio(v)/2
+	} else {
+		t = 0.5
+	}
+	return interpolate(s.Colors, t)
+}
+
+func (s Diverging) Extent() Range {
+	return s.Domain
+}
+
+func interpolate(colors []string, t float64) string {
+	switch len(colors) {
+	case 0:
+		return ""
+	case 1:
+		return colors[0]
+	}
+	t = math.Max(0, math.Min(1, t))
+	var (
+		pos  = t * float64(len(colors)-1)
+		i    = int(math.Floor(pos))
+		frac = pos - float64(i)
+	)
+	if i >= len(colors)-1 {
+		return colors[len(colors)-1]
+	}
+	c0, err0 := ParseColor(colors[i])
+	c1, err1 := ParseColor(colors[i+1])
+	if err0 != nil || err1 != nil {
+		if frac < 0.5 {
+			return colors[i]
+		}
+		return colors[i+1]
+	}
+	return c0.Mix(c1, frac).String()
+}
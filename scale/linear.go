@@ -0,0 +1,156 @@
+package scale
+
+import (
+	"math"
+)
+
+type Linear struct {
+	Domain Range
+	Range  Range
+	Clamp  bool
+	Format func(float64) string
+}
+
+func NewLinear(domain, rg Range) Linear {
+	return Linear{
+		Domain: domain,
+		Range:  rg,
+	}
+}
+
+func (s Linear) Scale(v float64) float64 {
+	if s.Clamp {
+		v = s.Domain.clamp(v)
+	}
+	return s.Range.lerp(s.Domain.ratio(v))
+}
+
+func (s Linear) Invert(v float64) float64 {
+	if s.Clamp {
+		v = s.Range.clamp(v)
+	}
+	return s.Domain.lerp(s.Range.ratio(v))
+}
+
+func (s Linear) Extent() Range {
+	return s.Range
+}
+
+func (s Linear) Nice(count int) Linear {
+	s.Domain = niceRange(s.Domain, count)
+	return s
+}
+
+func (s Linear) Values(count int) []float64 {
+	return tickValues(s.Domain.Starts, s.Domain.Ends, count)
+}
+
+func (s Linear) Ticks(count int) []Tick {
+	var (
+		values = s.Values(count)
+		format = s.Format
+	)
+	if format == nil {
+		format = formatStep(tickStep(s.Domain.Min(), s.Domain.Max(), count))
+	}
+	return makeTicks(values, s.Scale, format)
+}
+
+type Pow struct {
+	Domain   Range
+	Range    Range
+	Exponent float64
+	Clamp    bool
+	Format   func(float64) string
+}
+
+func NewPow(domain, rg Range, exp float64) Pow {
+	return Pow{
+		Domain:   domain,
+		Range:    rg,
+		Exponent: exp,
+	}
+}
+
+func NewSqrt(domain, rg Range) Pow {
+	return NewPow(domain, rg, 0.5)
+}
+
+func (s Pow) Scale(v float64) float64 {
+	if s.Clamp {
+		v = s.Domain.clamp(v)
+	}
+	var (
+		d = NewRange(s.pow(s.Domain.Starts), s.pow(s.Domain.Ends))
+		r = d.ratio(s.pow(v))
+	)
+	return s.Range.lerp(r)
+}
+
+func (s Pow) Invert(v float64) float64 {
+	if s.Clamp {
+		v = s.Range.clamp(v)
+	}
+	var (
+		d = NewRange(s.pow(s.Domain.Starts), s.pow(s.Domain.Ends))
+		r = d.lerp(s.Range.ratio(v))
+	)
+	return s.root(r)
+}
+
+func (s Pow) Extent() Range {
+	return s.Range
+}
+
+func (s Pow) Nice(count int) Pow {
+	s.Domain = niceRange(s.Domain, count)
+	return s
+}
+
+func (s Pow) Values(count int) []float64 {
+	return tickValues(s.Domain.Starts, s.Domain.Ends, count)
+}
+
+func (s Pow) Ticks(count int) []Tick {
+	var (
+		values = s.Values(count)
+		format = s.Format
+	)
+	if format == nil {
+		format = formatStep(tickStep(s.Domain.Min(), s.Domain.Max(), count))
+	}
+	return makeTicks(values, s.Scale, format)
+}
+
+func (s Pow) exponent() float64 {
+	if s.Exponent == 0 {
+		return 1
+	}
+	return s.Exponent
+}
+
+func (s Pow) pow(v float64) float64 {
+	if v < 0 {
+		return -math.Pow(-v, s.exponent())
+	}
+	return math.Pow(v, s.exponent())
+}
+
+func (s Pow) root(v float64) float64 {
+	if v < 0 {
+		return -math.Pow(-v, 1/s.exponent())
+	}
+	return math.Pow(v, 1/s.exponent())
+}
+
+func makeTicks(values []float64, scale func(float64) float64, format func(float64) string) []Tick {
+	ticks := make([]Tick, 0, len(values))
+	for _, v := range values {
+		t := Tick{
+			Offset: scale(v),
+			Label:  format(v),
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
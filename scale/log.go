@@ -0,0 +1,146 @@
+package scale
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+const defaultBase = 10
+
+type Log struct {
+	Domain Range
+	Range  Range
+	Base   float64
+	Clamp  bool
+	Format func(float64) string
+}
+
+func NewLog(domain, rg Range) Log {
+	return Log{
+		Domain: domain,
+		Range:  rg,
+		Base:   defaultBase,
+	}
+}
+
+func (s Log) Scale(v float64) float64 {
+	if s.Clamp {
+		v = s.Domain.clamp(v)
+	}
+	var (
+		d = NewRange(s.log(s.Domain.Starts), s.log(s.Domain.Ends))
+		r = d.ratio(s.log(v))
+	)
+	return s.Range.lerp(r)
+}
+
+func (s Log) Invert(v float64) float64 {
+	if s.Clamp {
+		v = s.Range.clamp(v)
+	}
+	var (
+		d = NewRange(s.log(s.Domain.Starts), s.log(s.Domain.Ends))
+		r = d.lerp(s.Range.ratio(v))
+	)
+	return s.exp(r)
+}
+
+func (s Log) Extent() Range {
+	return s.Range
+}
+
+func (s Log) Nice(count int) Log {
+	if count <= 0 {
+		count = defaultTicks
+	}
+	var (
+		lo, hi = math.Floor(s.log(s.Domain.Min())), math.Ceil(s.log(s.Domain.Max()))
+		step   = math.Max(1, math.Ceil((hi-lo)/float64(count)))
+		starts = s.exp(math.Floor(lo/step) * step)
+		ends   = s.exp(math.Ceil(hi/step) * step)
+	)
+	if s.Domain.Starts > s.Domain.Ends {
+		starts, ends = ends, starts
+	}
+	s.Domain = NewRange(starts, ends)
+	return s
+}
+
+func (s Log) Values(count int) []float64 {
+	if count <= 0 {
+		count = defaultTicks
+	}
+	var (
+		base   = s.base()
+		lo, hi = s.log(s.Domain.Min()), s.log(s.Domain.Max())
+		list   []float64
+	)
+	lo, hi = math.Floor(lo), math.Ceil(hi)
+	if hi-lo < float64(count) && base == math.Trunc(base) {
+		for p := lo; p <= hi; p++ {
+			for k := 1.0; k < base; k++ {
+				v := s.exp(p) * k
+				if s.Domain.Contains(v) {
+					list = append(list, v)
+				}
+			}
+		}
+		if len(list) <= count*2 {
+			return s.order(list)
+		}
+		list = list[:0]
+	}
+	step := math.Max(1, math.Ceil((hi-lo)/float64(count)))
+	for p := lo; p <= hi; p += step {
+		if v := s.exp(p); s.Domain.Contains(v) {
+			list = append(list, v)
+		}
+	}
+	return s.order(list)
+}
+
+func (s Log) Ticks(count int) []Tick {
+	format := s.Format
+	if format == nil {
+		format = func(v float64) string {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	return makeTicks(s.Values(count), s.Scale, format)
+}
+
+func (s Log) order(list []float64) []float64 {
+	sort.Float64s(list)
+	if s.Domain.Starts > s.Domain.Ends {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+	return list
+}
+
+func (s Log) negative() bool {
+	return s.Domain.Max() < 0
+}
+
+func (s Log) base() float64 {
+	if s.Base <= 0 || s.Base == 1 {
+		return defaultBase
+	}
+	return s.Base
+}
+
+func (s Log) log(v float64) float64 {
+	if s.negative() {
+		return -math.Log(-v) / math.Log(s.base())
+	}
+	return math.Log(v) / math.Log(s.base())
+}
+
+func (s Log) exp(v float64) float64 {
+	if s.negative() {
+		return -math.Pow(s.base(), -v)
+	}
+	return math.Pow(s.base(), v)
+}
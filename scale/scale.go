@@ -0,0 +1,147 @@
+package scale
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/midbel/svg"
+)
+
+const defaultTicks = 10
+
+type Range struct {
+	Starts float64
+	Ends   float64
+}
+
+func NewRange(starts, ends float64) Range {
+	return Range{
+		Starts: starts,
+		Ends:   ends,
+	}
+}
+
+func (r Range) Len() float64 {
+	return r.Ends - r.Starts
+}
+
+func (r Range) Min() float64 {
+	return math.Min(r.Starts, r.Ends)
+}
+
+func (r Range) Max() float64 {
+	return math.Max(r.Starts, r.Ends)
+}
+
+func (r Range) Contains(v float64) bool {
+	return v >= r.Min() && v <= r.Max()
+}
+
+func (r Range) clamp(v float64) float64 {
+	return math.Max(r.Min(), math.Min(r.Max(), v))
+}
+
+func (r Range) lerp(t float64) float64 {
+	return r.Starts + t*r.Len()
+}
+
+func (r Range) ratio(v float64) float64 {
+	if r.Len() == 0 {
+		return 0.5
+	}
+	return (v - r.Starts) / r.Len()
+}
+
+type Tick struct {
+	Offset float64
+	Label  string
+}
+
+type Scale interface {
+	Extent() Range
+	Ticks(int) []Tick
+}
+
+type Continuous interface {
+	Scale
+	Scale(float64) float64
+	Invert(float64) float64
+}
+
+func Pos(x, y Continuous, vx, vy float64) svg.Pos {
+	return svg.NewPos(x.Scale(vx), y.Scale(vy))
+}
+
+func tickStep(min, max float64, count int) float64 {
+	if count <= 0 {
+		count = defaultTicks
+	}
+	var (
+		step  = (max - min) / float64(count)
+		power = math.Pow(10, math.Floor(math.Log10(step)))
+		err   = step / power
+	)
+	switch {
+	case err >= math.Sqrt(50):
+		power *= 10
+	case err >= math.Sqrt(10):
+		power *= 5
+	case err >= math.Sqrt(2):
+		power *= 2
+	}
+	return power
+}
+
+func tickValues(min, max float64, count int) []float64 {
+	if min == max {
+		return []float64{min}
+	}
+	reverse := min > max
+	if reverse {
+		min, max = max, min
+	}
+	var (
+		step  = tickStep(min, max, count)
+		start = math.Ceil(min / step)
+		stop  = math.Floor(max / step)
+		list  []float64
+	)
+	if step <= 0 || math.IsInf(step, 0) || math.IsNaN(step) {
+		return nil
+	}
+	for i := start; i <= stop; i++ {
+		list = append(list, i*step)
+	}
+	if reverse {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+	return list
+}
+
+func niceRange(r Range, count int) Range {
+	var (
+		min, max = r.Min(), r.Max()
+		step     = tickStep(min, max, count)
+	)
+	if min == max || step <= 0 || math.IsInf(step, 0) || math.IsNaN(step) {
+		return r
+	}
+	min = math.Floor(min/step) * step
+	max = math.Ceil(max/step) * step
+	if r.Starts > r.Ends {
+		return NewRange(max, min)
+	}
+	return NewRange(min, max)
+}
+
+func formatStep(step float64) func(float64) string {
+	prec := 0
+	if step > 0 && step < 1 {
+		prec = int(math.Ceil(-math.Log10(step) - 1e-9))
+	}
+	return func(v float64) string {
+		return strconv.FormatFloat(v, 'f', prec, 64)
+	}
+}
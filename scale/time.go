@@ -0,0 +1,243 @@
+package scale
+
+import (
+	"math"
+	"time"
+)
+
+type Unit int
+
+const (
+	UnitSecond Unit = iota
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+)
+
+type Interval struct {
+	Unit   Unit
+	Step   int
+	Layout string
+}
+
+var intervals = []Interval{
+	{Unit: UnitSecond, Step: 1, Layout: "15:04:05"},
+	{Unit: UnitSecond, Step: 5, Layout: "15:04:05"},
+	{Unit: UnitSecond, Step: 15, Layout: "15:04:05"},
+	{Unit: UnitSecond, Step: 30, Layout: "15:04:05"},
+	{Unit: UnitMinute, Step: 1, Layout: "15:04"},
+	{Unit: UnitMinute, Step: 5, Layout: "15:04"},
+	{Unit: UnitMinute, Step: 15, Layout: "15:04"},
+	{Unit: UnitMinute, Step: 30, Layout: "15:04"},
+	{Unit: UnitHour, Step: 1, Layout: "15:04"},
+	{Unit: UnitHour, Step: 3, Layout: "15:04"},
+	{Unit: UnitHour, Step: 6, Layout: "15:04"},
+	{Unit: UnitHour, Step: 12, Layout: "Jan 02 15:04"},
+	{Unit: UnitDay, Step: 1, Layout: "Jan 02"},
+	{Unit: UnitDay, Step: 2, Layout: "Jan 02"},
+	{Unit: UnitWeek, Step: 1, Layout: "Jan 02"},
+	{Unit: UnitMonth, Step: 1, Layout: "Jan 2006"},
+	{Unit: UnitMonth, Step: 3, Layout: "Jan 2006"},
+	{Unit: UnitYear, Step: 1, Layout: "2006"},
+}
+
+func SelectInterval(starts, ends time.Time, count int) Interval {
+	if count <= 0 {
+		count = defaultTicks
+	}
+	span := ends.Sub(starts)
+	if span < 0 {
+		span = -span
+	}
+	target := span / time.Duration(count)
+	for _, i := range intervals {
+		if i.approx() >= target {
+			return i
+		}
+	}
+	years := span.Hours() / (24 * 365)
+	step := tickStep(0, years, count)
+	return Interval{
+		Unit:   UnitYear,
+		Step:   int(math.Max(1, math.Round(step))),
+		Layout: "2006",
+	}
+}
+
+func (i Interval) Floor(t time.Time) time.Time {
+	var (
+		y, m, d  = t.Date()
+		h, mi, s = t.Clock()
+		loc      = t.Location()
+		k        = i.step()
+	)
+	switch i.Unit {
+	case UnitSecond:
+		return time.Date(y, m, d, h, mi, s-s%k, 0, loc)
+	case UnitMinute:
+		return time.Date(y, m, d, h, mi-mi%k, 0, 0, loc)
+	case UnitHour:
+		return time.Date(y, m, d, h-h%k, 0, 0, 0, loc)
+	case UnitDay:
+		return time.Date(y, m, d-(d-1)%k, 0, 0, 0, 0, loc)
+	case UnitWeek:
+		wd := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-wd, 0, 0, 0, 0, loc)
+	case UnitMonth:
+		return time.Date(y, m-time.Month((int(m)-1)%k), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(y-y%k, 1, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+func (i Interval) Next(t time.Time) time.Time {
+	k := i.step()
+	switch i.Unit {
+	case UnitSecond:
+		return t.Add(time.Duration(k) * time.Second)
+	case UnitMinute:
+		return t.Add(time.Duration(k) * time.Minute)
+	case UnitHour:
+		return t.Add(time.Duration(k) * time.Hour)
+	case UnitDay:
+		return t.AddDate(0, 0, k)
+	case UnitWeek:
+		return t.AddDate(0, 0, 7*k)
+	case UnitMonth:
+		return t.AddDate(0, k, 0)
+	default:
+		return t.AddDate(k, 0, 0)
+	}
+}
+
+func (i Interval) Range(starts, ends time.Time) []time.Time {
+	if ends.Before(starts) {
+		starts, ends = ends, starts
+	}
+	var list []time.Time
+	for t := i.Floor(starts); !t.After(ends); t = i.Next(t) {
+		if t.Before(starts) {
+			continue
+		}
+		list = append(list, t)
+	}
+	return list
+}
+
+func (i Interval) step() int {
+	if i.Step <= 0 {
+		return 1
+	}
+	return i.Step
+}
+
+func (i Interval) approx() time.Duration {
+	k := time.Duration(i.step())
+	switch i.Unit {
+	case UnitSecond:
+		return k * time.Second
+	case UnitMinute:
+		return k * time.Minute
+	case UnitHour:
+		return k * time.Hour
+	case UnitDay:
+		return k * 24 * time.Hour
+	case UnitWeek:
+		return k * 7 * 24 * time.Hour
+	case UnitMonth:
+		return k * 30 * 24 * time.Hour
+	default:
+		return k * 365 * 24 * time.Hour
+	}
+}
+
+type Time struct {
+	Starts time.Time
+	Ends   time.Time
+	Range  Range
+	Clamp  bool
+	Format string
+}
+
+func NewTime(starts, ends time.Time, rg Range) Time {
+	return Time{
+		Starts: starts,
+		Ends:   ends,
+		Range:  rg,
+	}
+}
+
+func (s Time) Scale(t time.Time) float64 {
+	return s.linear().Scale(unix(t))
+}
+
+func (s Time) Invert(v float64) time.Time {
+	f := s.linear().Invert(v)
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)).In(s.Starts.Location())
+}
+
+func (s Time) Extent() Range {
+	return s.Range
+}
+
+func (s Time) Nice(count int) Time {
+	var (
+		i        = SelectInterval(s.Starts, s.Ends, count)
+		starts   = s.Starts
+		ends     = s.Ends
+		reversed = ends.Before(starts)
+	)
+	if reversed {
+		starts, ends = ends, starts
+	}
+	starts = i.Floor(starts)
+	if f := i.Floor(ends); f.Before(ends) {
+		ends = i.Next(f)
+	}
+	if reversed {
+		starts, ends = ends, starts
+	}
+	s.Starts, s.Ends = starts, ends
+	return s
+}
+
+func (s Time) Values(count int) []time.Time {
+	i := SelectInterval(s.Starts, s.Ends, count)
+	return i.Range(s.Starts, s.Ends)
+}
+
+func (s Time) Ticks(count int) []Tick {
+	var (
+		i      = SelectInterval(s.Starts, s.Ends, count)
+		layout = s.Format
+		ticks  []Tick
+	)
+	if layout == "" {
+		layout = i.Layout
+	}
+	for _, t := range i.Range(s.Starts, s.Ends) {
+		k := Tick{
+			Offset: s.Scale(t),
+			Label:  t.Format(layout),
+		}
+		ticks = append(ticks, k)
+	}
+	return ticks
+}
+
+func (s Time) linear() Linear {
+	d := NewRange(unix(s.Starts), unix(s.Ends))
+	return Linear{
+		Domain: d,
+		Range:  s.Range,
+		Clamp:  s.Clamp,
+	}
+}
+
+func unix(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
@@ -1,9 +1,5 @@
 package svg
 
-import (
-	"strconv"
-)
-
 const (
 	defaultWidth  = 800
 	defaultHeight = 600
@@ -43,8 +39,9 @@ func (i *List) Append(e Element) {
 }
 
 func (i *List) Render(w Writer) {
+	d, _ := w.(*document)
 	for _, e := range i.List {
-		if e == nil {
+		if e == nil || d != nil && d.skipped(e) {
 			continue
 		}
 		e.Render(w)
@@ -57,10 +54,12 @@ type Defs struct {
 }
 
 func (d *Defs) Render(w Writer) {
-	attrs := d.Transform.Attributes()
-	writeElement(w, "defs", attrs, func() {
-		d.List.Render(w)
-	})
+	name, attrs := d.tag()
+	d.render(w, name, d.List, attrs...)
+}
+
+func (d *Defs) tag() (string, []Attribute) {
+	return "defs", []Attribute{d.Transform}
 }
 
 func (d *Defs) AsElement() Element {
@@ -83,7 +82,12 @@ func (u *Use) Render(w Writer) {
 		return
 	}
 	var list List
-	u.render(w, "use", list, u, u.Pos, u.Dim, u.Fill, u.Stroke, u.Transform)
+	name, attrs := u.tag()
+	u.render(w, name, list, attrs...)
+}
+
+func (u *Use) tag() (string, []Attribute) {
+	return "use", []Attribute{u, u.Pos, u.Dim, u.Fill, u.Stroke, u.Transform}
 }
 
 func (u *Use) AsElement() Element {
@@ -100,6 +104,7 @@ type SVG struct {
 	List
 
 	OmitProlog bool
+	StyleMode  StyleMode
 	Ratio
 	ViewBox
 	Pos
@@ -115,14 +120,27 @@ func NewSVG() SVG {
 }
 
 func (s *SVG) Render(w Writer) {
-	if !s.OmitProlog {
-		w.WriteString(prolog)
+	if _, ok := w.(*document); !ok {
+		renderDocument(w, s)
+		return
+	}
+	name, attrs := s.tag()
+	s.render(w, name, s.List, attrs...)
+}
+
+func (s *SVG) tag() (string, []Attribute) {
+	var (
+		box   = s.ViewBox
+		attrs = []Attribute{s}
+	)
+	if box.IsZero() {
+		box.Pos = NewPos(0, 0)
+		box.Dim = s.Dim
 	}
-	if s.ViewBox.IsZero() {
-		s.ViewBox.Pos = NewPos(0, 0)
-		s.ViewBox.Dim = s.Dim
+	if !s.Pos.IsZero() {
+		attrs = append(attrs, s.Pos)
 	}
-	s.render(w, "svg", s.List, s, s.Pos, s.Dim, s.ViewBox)
+	return "svg", append(attrs, s.Dim, box)
 }
 
 func (s *SVG) AsElement() Element {
@@ -132,7 +150,9 @@ func (s *SVG) AsElement() Element {
 func (s *SVG) Attributes() []string {
 	var attrs []string
 	attrs = append(attrs, appendString("xmlns", namespace))
-	attrs = append(attrs, s.Ratio.Attributes()...)
+	if !s.ViewBox.IsZero() {
+		attrs = append(attrs, s.Ratio.Attributes()...)
+	}
 	return attrs
 }
 
@@ -151,7 +171,12 @@ func NewClipPath() ClipPath {
 }
 
 func (c *ClipPath) Render(w Writer) {
-	c.render(w, "clipPath", c.List, c.Fill, c.Stroke, c.Transform)
+	name, attrs := c.tag()
+	c.render(w, name, c.List, attrs...)
+}
+
+func (c *ClipPath) tag() (string, []Attribute) {
+	return "clipPath", []Attribute{c.Fill, c.Stroke, c.Transform}
 }
 
 func (c *ClipPath) AsElement() Element {
@@ -198,10 +223,15 @@ func (t *TextPath) Render(w Writer) {
 	as = append(as, appendString("text-anchor", t.Anchor))
 	writeElement(w, "text", as, func() {
 		list := NewList(Literal(t.Literal))
-		t.render(w, "textPath", list, t, t.Fill, t.Stroke, t.Transform)
+		name, attrs := t.tag()
+		t.render(w, name, list, attrs...)
 	})
 }
 
+func (t *TextPath) tag() (string, []Attribute) {
+	return "textPath", []Attribute{t, t.Fill, t.Stroke, t.Transform}
+}
+
 func (t *TextPath) AsElement() Element {
 	return t
 }
@@ -243,7 +273,12 @@ type Group struct {
 }
 
 func (g *Group) Render(w Writer) {
-	g.render(w, "g", g.List, g.Stroke, g.Fill, g.Transform)
+	name, attrs := g.tag()
+	g.render(w, name, g.List, attrs...)
+}
+
+func (g *Group) tag() (string, []Attribute) {
+	return "g", []Attribute{g.Stroke, g.Fill, g.Transform}
 }
 
 func (g *Group) AsElement() Element {
@@ -270,7 +305,12 @@ func (i *Image) Render(w Writer) {
 		return
 	}
 	var list List
-	i.render(w, "image", list, i, i.Pos, i.Dim)
+	name, attrs := i.tag()
+	i.render(w, name, list, attrs...)
+}
+
+func (i *Image) tag() (string, []Attribute) {
+	return "image", []Attribute{i, i.Pos, i.Dim}
 }
 
 func (i *Image) AsElement() Element {
@@ -299,7 +339,12 @@ type Mask struct {
 }
 
 func (m *Mask) Render(w Writer) {
-	m.render(w, "mask", m.List, m.Pos, m.Dim, m.Fill, m.Stroke, m.Transform)
+	name, attrs := m.tag()
+	m.render(w, name, m.List, attrs...)
+}
+
+func (m *Mask) tag() (string, []Attribute) {
+	return "mask", []Attribute{m.Pos, m.Dim, m.Fill, m.Stroke, m.Transform}
 }
 
 func (m *Mask) AsElement() Element {
@@ -320,7 +365,12 @@ type Rect struct {
 }
 
 func (r *Rect) Render(w Writer) {
-	r.render(w, "rect", r.List, r, r.Dim, r.Pos, r.Stroke, r.Transform, r.Fill)
+	name, attrs := r.tag()
+	r.render(w, name, r.List, attrs...)
+}
+
+func (r *Rect) tag() (string, []Attribute) {
+	return "rect", []Attribute{r, r.Dim, r.Pos, r.Stroke, r.Transform, r.Fill}
 }
 
 func (r *Rect) AsElement() Element {
@@ -349,7 +399,12 @@ type Polygon struct {
 }
 
 func (p *Polygon) Render(w Writer) {
-	p.render(w, "polygon", p.List, p, p.Fill, p.Stroke, p.Transform)
+	name, attrs := p.tag()
+	p.render(w, name, p.List, attrs...)
+}
+
+func (p *Polygon) tag() (string, []Attribute) {
+	return "polygon", []Attribute{p, p.Fill, p.Stroke, p.Transform}
 }
 
 func (p *Polygon) AsElement() Element {
@@ -378,7 +433,12 @@ type Ellipse struct {
 }
 
 func (e *Ellipse) Render(w Writer) {
-	e.render(w, "ellipse", e.List, e, e.Stroke, e.Fill, e.Transform)
+	name, attrs := e.tag()
+	e.render(w, name, e.List, attrs...)
+}
+
+func (e *Ellipse) tag() (string, []Attribute) {
+	return "ellipse", []Attribute{e, e.Stroke, e.Fill, e.Transform}
 }
 
 func (e *Ellipse) AsElement() Element {
@@ -405,7 +465,12 @@ type Circle struct {
 }
 
 func (c *Circle) Render(w Writer) {
-	c.render(w, "circle", c.List, c, c.Fill, c.Stroke, c.Transform)
+	name, attrs := c.tag()
+	c.render(w, name, c.List, attrs...)
+}
+
+func (c *Circle) tag() (string, []Attribute) {
+	return "circle", []Attribute{c, c.Fill, c.Stroke, c.Transform}
 }
 
 func (c *Circle) AsElement() Element {
@@ -441,7 +506,12 @@ func NewText(str string) Text {
 }
 
 func (t *Text) Render(w Writer) {
-	t.render(w, "text", t.List, t, t.Pos, t.Font, t.Fill, t.Stroke, t.Transform)
+	name, attrs := t.tag()
+	t.render(w, name, t.List, attrs...)
+}
+
+func (t *Text) tag() (string, []Attribute) {
+	return "text", []Attribute{t, t.Pos, t.Font, t.Fill, t.Stroke, t.Transform}
 }
 
 func (t *Text) AsElement() Element {
@@ -480,7 +550,15 @@ type TextSpan struct {
 
 func (t *TextSpan) Render(w Writer) {
 	list := NewList(Literal(t.Literal))
-	t.render(w, "tspan", list, t, t.Pos)
+	name, attrs := t.tag()
+	t.render(w, name, list, attrs...)
+}
+
+func (t *TextSpan) tag() (string, []Attribute) {
+	if t.Pos.IsZero() {
+		return "tspan", []Attribute{t}
+	}
+	return "tspan", []Attribute{t, t.Pos}
 }
 
 func (t *TextSpan) AsElement() Element {
@@ -508,6 +586,7 @@ type Line struct {
 	Fill
 	Stroke
 	Transform
+	Markers
 }
 
 func NewLine(starts, ends Pos) Line {
@@ -519,7 +598,12 @@ func NewLine(starts, ends Pos) Line {
 
 func (i *Line) Render(w Writer) {
 	var list List
-	i.render(w, "line", list, i, i.Stroke, i.Fill, i.Transform)
+	name, attrs := i.tag()
+	i.render(w, name, list, attrs...)
+}
+
+func (i *Line) tag() (string, []Attribute) {
+	return "line", []Attribute{i, i.Stroke, i.Fill, i.Transform, i.Markers}
 }
 
 func (i *Line) AsElement() Element {
@@ -542,11 +626,17 @@ type PolyLine struct {
 	Stroke
 	Fill
 	Transform
+	Markers
 }
 
 func (p *PolyLine) Render(w Writer) {
 	var list List
-	p.render(w, "polyline", list, p, p.Fill, p.Stroke, p.Transform)
+	name, attrs := p.tag()
+	p.render(w, name, list, attrs...)
+}
+
+func (p *PolyLine) tag() (string, []Attribute) {
+	return "polyline", []Attribute{p, p.Fill, p.Stroke, p.Transform, p.Markers}
 }
 
 func (p *PolyLine) AsElement() Element {
@@ -564,10 +654,55 @@ func (p *PolyLine) Attributes() []string {
 
 type Marker struct {
 	node
+	List
+
+	ViewBox
+	Width  float64
+	Height float64
+	RefX   float64
+	RefY   float64
+	Orient string
+	Units  string
+}
+
+func NewMarker(id string, width, height float64) Marker {
+	var m Marker
+	m.Id = id
+	m.Width = width
+	m.Height = height
+	m.Orient = "auto"
+	return m
 }
 
 func (m *Marker) Render(w Writer) {
+	name, attrs := m.tag()
+	m.render(w, name, m.List, attrs...)
+}
 
+func (m *Marker) tag() (string, []Attribute) {
+	return "marker", []Attribute{m}
+}
+
+func (m *Marker) Attributes() []string {
+	var attrs []string
+	if !m.ViewBox.IsZero() {
+		attrs = append(attrs, m.ViewBox.Attributes()...)
+	}
+	if m.Width != 0 {
+		attrs = append(attrs, appendFloat("markerWidth", m.Width))
+	}
+	if m.Height != 0 {
+		attrs = append(attrs, appendFloat("markerHeight", m.Height))
+	}
+	attrs = append(attrs, appendFloat("refX", m.RefX))
+	attrs = append(attrs, appendFloat("refY", m.RefY))
+	if m.Orient != "" {
+		attrs = append(attrs, appendString("orient", m.Orient))
+	}
+	if m.Units != "" {
+		attrs = append(attrs, appendString("markerUnits", m.Units))
+	}
+	return attrs
 }
 
 func (m *Marker) AsElement() Element {
@@ -603,22 +738,40 @@ type Style struct {
 	Media   string
 	Type    string
 	Content string
+	Sheet   *StyleSheet
 }
 
 func (s *Style) Render(w Writer) {
-	if s.Type == "" {
-		s.Type = "text/css"
+	content := s.Content
+	if s.Sheet != nil && s.Sheet.Len() > 0 {
+		if content != "" {
+			content += "\n"
+		}
+		content += s.Sheet.String()
+	}
+	name, attrs := s.tag()
+	s.render(w, name, NewList(Literal(cdata(content, ""))), attrs...)
+}
+
+func (s *Style) tag() (string, []Attribute) {
+	return "style", []Attribute{s}
+}
+
+func (s *Style) Attributes() []string {
+	var (
+		kind  = s.Type
+		media = s.Media
+	)
+	if kind == "" {
+		kind = "text/css"
 	}
-	if s.Media == "" {
-		s.Media = "all"
+	if media == "" {
+		media = "all"
 	}
-	attrs := []string{
-		appendString("type", s.Type),
-		appendString("media", s.Media),
+	return []string{
+		appendString("type", kind),
+		appendString("media", media),
 	}
-	writeElement(w, "style", attrs, func() {
-		writeData(w, s.Content, "")
-	})
 }
 
 func (s *Style) AsElement() Element {
@@ -634,20 +787,30 @@ type Script struct {
 }
 
 func (s *Script) Render(w Writer) {
-	var attrs []string
-	if s.Type == "" {
-		s.Type = "application/ecmascript"
+	name, attrs := s.tag()
+	s.render(w, name, NewList(Literal(cdata(s.Content, "//"))), attrs...)
+}
+
+func (s *Script) tag() (string, []Attribute) {
+	return "script", []Attribute{s}
+}
+
+func (s *Script) Attributes() []string {
+	var (
+		attrs []string
+		kind  = s.Type
+	)
+	if kind == "" {
+		kind = "application/ecmascript"
 	}
-	attrs = append(attrs, appendString("type", s.Type))
+	attrs = append(attrs, appendString("type", kind))
 	if s.Cors != "" {
 		attrs = append(attrs, appendString("crossorigin", s.Cors))
 	}
 	if s.Url != "" {
 		attrs = append(attrs, appendString("href", s.Url))
 	}
-	writeElement(w, "script", attrs, func() {
-		writeData(w, s.Content, "//")
-	})
+	return attrs
 }
 
 func (s *Script) AsElement() Element {
@@ -661,11 +824,17 @@ type Path struct {
 	Fill
 	Stroke
 	Transform
+	Markers
 }
 
 func (p *Path) Render(w Writer) {
 	var list List
-	p.render(w, "path", list, p, p.Fill, p.Stroke, p.Transform)
+	name, attrs := p.tag()
+	p.render(w, name, list, attrs...)
+}
+
+func (p *Path) tag() (string, []Attribute) {
+	return "path", []Attribute{p, p.Fill, p.Stroke, p.Transform, p.Markers}
 }
 
 func (p *Path) AsElement() Element {
@@ -856,7 +1025,7 @@ func (c command) String() string {
 				buf = append(buf, space)
 			}
 			v := c.values[i][j]
-			buf = strconv.AppendFloat(buf, v, 'f', getPrecision(v), 64)
+			buf = appendNumber(buf, v)
 		}
 	}
 	return string(buf)
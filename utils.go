@@ -5,5 +5,5 @@ import (
 )
 
 func UrlFor(ident string) string {
-	return fmt.Sprintf("url(#%s", ident)
+	return fmt.Sprintf("url(#%s)", ident)
 }
\ No newline at end of file
@@ -0,0 +1,63 @@
+package svg
+
+import (
+	"errors"
+)
+
+var (
+	ErrSkip = errors.New("skip children")
+	ErrStop = errors.New("stop walking")
+)
+
+type Container interface {
+	Element
+	Children() []Element
+}
+
+type Visitor interface {
+	Visit(Element, int) error
+}
+
+type VisitorFunc func(Element, int) error
+
+func (fn VisitorFunc) Visit(e Element, depth int) error {
+	return fn(e, depth)
+}
+
+func (i *List) Children() []Element {
+	return i.List
+}
+
+func Walk(e Element, fn func(Element, int) error) error {
+	return WalkVisitor(e, VisitorFunc(fn))
+}
+
+func WalkVisitor(e Element, v Visitor) error {
+	err := walk(e, v, 0)
+	if errors.Is(err, ErrStop) {
+		err = nil
+	}
+	return err
+}
+
+func walk(e Element, v Visitor, depth int) error {
+	if e == nil {
+		return nil
+	}
+	if err := v.Visit(e, depth); err != nil {
+		if errors.Is(err, ErrSkip) {
+			return nil
+		}
+		return err
+	}
+	c, ok := e.(Container)
+	if !ok {
+		return nil
+	}
+	for _, e := range c.Children() {
+		if err := walk(e, v, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package svg
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultLineHeight = 1.2
+	defaultCharWidth  = 0.6
+)
+
+const (
+	AlignStart   = "start"
+	AlignMiddle  = "middle"
+	AlignEnd     = "end"
+	AlignJustify = "justify"
+)
+
+type WrapMode int
+
+const (
+	WrapGreedy WrapMode = iota
+	WrapOptimal
+)
+
+type Wrapper struct {
+	Width      float64
+	LineHeight float64
+	Align      string
+	Mode       WrapMode
+	MaxLines   int
+	Ellipsis   string
+	Hyphen     string
+
+	Measure   func(string, Font) float64
+	Hyphenate func(string) []string
+}
+
+func (w Wrapper) Lines(str string, font Font) []string {
+	var lines []string
+	for _, i := range w.wrap(str, font) {
+		lines = append(lines, i.str)
+	}
+	return lines
+}
+
+func (t *Text) Wrap(str string, w Wrapper) {
+	var (
+		lines  = w.wrap(str, t.Font)
+		height = w.LineHeight
+		x      = t.Pos.X
+	)
+	if height <= 0 {
+		height = fontSize(t.Font) * defaultLineHeight
+	}
+	switch w.Align {
+	case AlignMiddle:
+		x += w.Width / 2
+		t.Anchor = AlignMiddle
+	case AlignEnd:
+		x += w.Width
+		t.Anchor = AlignEnd
+	default:
+		t.Anchor = AlignStart
+	}
+	t.List.List = t.List.List[:0]
+	for i, ln := range lines {
+		s := TextSpan{
+			Literal: ln.str,
+			Pos:     NewPos(x, t.Pos.Y+float64(i)*height),
+		}
+		if w.Align == AlignJustify && !ln.last && w.Width > 0 {
+			s.Length = w.Width
+			s.Adjust = "spacing"
+		}
+		t.Append(s.AsElement())
+	}
+}
+
+type line struct {
+	str  string
+	last bool
+}
+
+func (w Wrapper) wrap(str string, font Font) []line {
+	var lines []line
+	for _, para := range strings.Split(str, "\n") {
+		frags := w.fragments(para)
+		if len(frags) == 0 {
+			lines = append(lines, line{last: true})
+			continue
+		}
+		var list []line
+		if w.Width <= 0 {
+			list = append(list, line{str: joinFragments(frags, w.hyphen())})
+		} else if w.Mode == WrapOptimal {
+			list = w.optimal(frags, font)
+		} else {
+			list = w.greedy(frags, font)
+		}
+		list[len(list)-1].last = true
+		lines = append(lines, list...)
+	}
+	if w.MaxLines > 0 && len(lines) > w.MaxLines {
+		lines = lines[:w.MaxLines]
+		last := &lines[len(lines)-1]
+		last.str = w.truncate(last.str, font)
+		last.last = true
+	}
+	return lines
+}
+
+func (w Wrapper) greedy(frags []fragment, font Font) []line {
+	var (
+		lines []line
+		start int
+	)
+	for start < len(frags) {
+		end := start + 1
+		for end < len(frags) {
+			str := joinFragments(frags[start:end+1], w.hyphen())
+			if w.measure(str, font) > w.Width {
+				break
+			}
+			end++
+		}
+		lines = append(lines, line{str: joinFragments(frags[start:end], w.hyphen())})
+		start = end
+	}
+	return lines
+}
+
+func (w Wrapper) optimal(frags []fragment, font Font) []line {
+	var (
+		size    = len(frags)
+		costs   = make([]float64, size+1)
+		breaks  = make([]int, size+1)
+		penalty = math.Pow(w.Width/4, 2)
+	)
+	for i := 1; i <= size; i++ {
+		costs[i] = math.Inf(1)
+		for j := i - 1; j >= 0; j-- {
+			if math.IsInf(costs[j], 1) {
+				continue
+			}
+			var (
+				str   = joinFragments(frags[j:i], w.hyphen())
+				width = w.measure(str, font)
+				cost  float64
+			)
+			if width > w.Width && i-j > 1 {
+				break
+			}
+			if i < size {
+				cost = math.Pow(math.Max(w.Width-width, 0), 2)
+				if !frags[i-1].eow {
+					cost += penalty
+				}
+			}
+			if c := costs[j] + cost; c < costs[i] {
+				costs[i] = c
+				breaks[i] = j
+			}
+		}
+	}
+	var lines []line
+	for i := size; i > 0; i = breaks[i] {
+		str := joinFragments(frags[breaks[i]:i], w.hyphen())
+		lines = append(lines, line{str: str})
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
+
+func (w Wrapper) truncate(str string, font Font) string {
+	if w.Width <= 0 {
+		return str + w.Ellipsis
+	}
+	for str != "" && w.measure(str+w.Ellipsis, font) > w.Width {
+		_, z := utf8.DecodeLastRuneInString(str)
+		str = strings.TrimRight(str[:len(str)-z], " ")
+	}
+	return str + w.Ellipsis
+}
+
+func (w Wrapper) measure(str string, font Font) float64 {
+	if w.Measure != nil {
+		return w.Measure(str, font)
+	}
+	return EstimateWidth(str, font)
+}
+
+func EstimateWidth(str string, font Font) float64 {
+	return float64(utf8.RuneCountInString(str)) * fontSize(font) * defaultCharWidth
+}
+
+func (w Wrapper) hyphen() string {
+	if w.Hyphen == "" {
+		return "-"
+	}
+	return w.Hyphen
+}
+
+type fragment struct {
+	str string
+	eow bool
+}
+
+func (w Wrapper) fragments(str string) []fragment {
+	var frags []fragment
+	for _, word := range strings.Fields(str) {
+		parts := []string{word}
+		if w.Hyphenate != nil {
+			if ps := w.Hyphenate(word); len(ps) > 0 {
+				parts = ps
+			}
+		}
+		for i := range parts {
+			f := fragment{
+				str: parts[i],
+				eow: i == len(parts)-1,
+			}
+			frags = append(frags, f)
+		}
+	}
+	return frags
+}
+
+func joinFragments(frags []fragment, hyphen string) string {
+	var b strings.Builder
+	for i, f := range frags {
+		if i > 0 && frags[i-1].eow {
+			b.WriteByte(space)
+		}
+		b.WriteString(f.str)
+	}
+	if n := len(frags); n > 0 && !frags[n-1].eow {
+		b.WriteString(hyphen)
+	}
+	return b.String()
+}
+
+func fontSize(f Font) float64 {
+	if f.Size <= 0 {
+		return DefaultFontSize
+	}
+	return f.Size
+}
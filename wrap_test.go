@@ -0,0 +1,151 @@
+package svg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWrapperLines(t *testing.T) {
+	data := []struct {
+		Name  string
+		Input string
+		Wrap  Wrapper
+		Want  []string
+	}{
+		{
+			Name:  "greedy",
+			Input: "the quick brown fox",
+			Wrap:  Wrapper{Width: 10},
+			Want:  []string{"the quick", "brown fox"},
+		},
+		{
+			Name:  "exact width",
+			Input: "aaaa bbbbb",
+			Wrap:  Wrapper{Width: 10},
+			Want:  []string{"aaaa bbbbb"},
+		},
+		{
+			Name:  "over width",
+			Input: "aaaa bbbbb",
+			Wrap:  Wrapper{Width: 9},
+			Want:  []string{"aaaa", "bbbbb"},
+		},
+		{
+			Name:  "long word",
+			Input: "a supercalifragilistic word",
+			Wrap:  Wrapper{Width: 5},
+			Want:  []string{"a", "supercalifragilistic", "word"},
+		},
+		{
+			Name:  "long word optimal",
+			Input: "a supercalifragilistic word",
+			Wrap:  Wrapper{Width: 5, Mode: WrapOptimal},
+			Want:  []string{"a", "supercalifragilistic", "word"},
+		},
+		{
+			Name:  "optimal",
+			Input: "aaa bb cc ddddd",
+			Wrap:  Wrapper{Width: 6, Mode: WrapOptimal},
+			Want:  []string{"aaa", "bb cc", "ddddd"},
+		},
+		{
+			Name:  "no width",
+			Input: "the quick brown fox",
+			Want:  []string{"the quick brown fox"},
+		},
+		{
+			Name:  "paragraphs",
+			Input: "a\n\nb",
+			Wrap:  Wrapper{Width: 10},
+			Want:  []string{"a", "", "b"},
+		},
+		{
+			Name:  "hyphen",
+			Input: "text wrapping",
+			Wrap:  Wrapper{Width: 10, Hyphenate: syllables},
+			Want:  []string{"text wrap-", "ping"},
+		},
+		{
+			Name:  "custom hyphen",
+			Input: "text wrapping",
+			Wrap:  Wrapper{Width: 10, Hyphen: "~", Hyphenate: syllables},
+			Want:  []string{"text wrap~", "ping"},
+		},
+		{
+			Name:  "ellipsis",
+			Input: "the quick brown fox jumps",
+			Wrap:  Wrapper{Width: 10, MaxLines: 2, Ellipsis: "..."},
+			Want:  []string{"the quick", "brown f..."},
+		},
+		{
+			Name:  "ellipsis fits",
+			Input: "the quick brown fox jumps",
+			Wrap:  Wrapper{Width: 12, MaxLines: 2, Ellipsis: "..."},
+			Want:  []string{"the quick", "brown fox..."},
+		},
+		{
+			Name:  "ellipsis no width",
+			Input: "a\nb\nc",
+			Wrap:  Wrapper{MaxLines: 2, Ellipsis: "..."},
+			Want:  []string{"a", "b..."},
+		},
+		{
+			Name:  "max lines",
+			Input: "the quick brown fox",
+			Wrap:  Wrapper{Width: 10, MaxLines: 2, Ellipsis: "..."},
+			Want:  []string{"the quick", "brown fox"},
+		},
+	}
+	for _, d := range data {
+		d.Wrap.Measure = runeWidth
+		got := d.Wrap.Lines(d.Input, NewFont(10))
+		if !reflect.DeepEqual(got, d.Want) {
+			t.Errorf("%s: lines mismatched: want %q, got %q", d.Name, d.Want, got)
+		}
+	}
+}
+
+func TestTextWrap(t *testing.T) {
+	var (
+		text = NewText("")
+		wrap = Wrapper{
+			Width:      10,
+			LineHeight: 12,
+			Align:      AlignJustify,
+			Measure:    runeWidth,
+		}
+	)
+	text.Pos = NewPos(5, 20)
+	text.Wrap("the quick brown fox", wrap)
+
+	want := []TextSpan{
+		{Literal: "the quick", Pos: NewPos(5, 20), Length: 10, Adjust: "spacing"},
+		{Literal: "brown fox", Pos: NewPos(5, 32)},
+	}
+	if len(text.List.List) != len(want) {
+		t.Fatalf("want %d spans, got %d", len(want), len(text.List.List))
+	}
+	for i, e := range text.List.List {
+		s, ok := e.(*TextSpan)
+		if !ok {
+			t.Errorf("span %d: unexpected element %T", i, e)
+			continue
+		}
+		if s.Literal != want[i].Literal || s.Pos != want[i].Pos || s.Length != want[i].Length || s.Adjust != want[i].Adjust {
+			t.Errorf("span %d: want %+v, got %+v", i, want[i], *s)
+		}
+	}
+}
+
+func runeWidth(str string, _ Font) float64 {
+	return float64(utf8.RuneCountInString(str))
+}
+
+func syllables(word string) []string {
+	if i := strings.Index(word, "ping"); i > 0 {
+		return []string{word[:i], word[i:]}
+	}
+	return nil
+}